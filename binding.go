@@ -0,0 +1,284 @@
+package gooo
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"mime"
+	"net/http"
+)
+
+// 常用内容类型
+const (
+	MIMEJSON              = "application/json"
+	MIMEXML               = "application/xml"
+	MIMEXML2              = "text/xml"
+	MIMEHTML              = "text/html"
+	MIMEPlain             = "text/plain"
+	MIMEPOSTForm          = "application/x-www-form-urlencoded"
+	MIMEMultipartPOSTForm = "multipart/form-data"
+	MIMEYAML              = "application/yaml"
+	MIMEYAML2             = "application/x-yaml"
+	MIMEMsgPack           = "application/msgpack"
+	MIMEMsgPack2          = "application/x-msgpack"
+	MIMEProtoBuf          = "application/x-protobuf"
+	MIMETOML              = "application/toml"
+)
+
+// defaultMultipartMemory Engine.MaxMultipartMemory 的默认值
+const defaultMultipartMemory = 32 << 20 // 32 MB
+
+// ErrEmptyBody 请求体为空
+var ErrEmptyBody = errors.New("empty request body")
+
+// Binding 将请求中的数据解码到结构体
+type Binding interface {
+	Name() string
+	Bind(req *http.Request, obj any) error
+}
+
+// 内置绑定器
+var (
+	BindingJSON      Binding = jsonBinding{}
+	BindingXML       Binding = xmlBinding{}
+	BindingForm      Binding = formBinding{}
+	BindingQuery     Binding = queryBinding{}
+	BindingMultipart Binding = multipartBinding{}
+	BindingHeader    Binding = headerBinding{}
+)
+
+// bindingFor 根据请求方法和内容类型选择绑定器
+func bindingFor(method, contentType string) Binding {
+	if method == http.MethodGet || method == http.MethodHead {
+		return BindingForm
+	}
+	switch contentType {
+	case MIMEJSON:
+		return BindingJSON
+	case MIMEXML, MIMEXML2:
+		return BindingXML
+	case MIMEMultipartPOSTForm:
+		return BindingMultipart
+	default:
+		return BindingForm
+	}
+}
+
+type jsonBinding struct{}
+
+func (jsonBinding) Name() string { return "json" }
+
+func (jsonBinding) Bind(req *http.Request, obj any) error {
+	if req == nil || req.Body == nil || req.Body == http.NoBody {
+		return ErrEmptyBody
+	}
+	return json.NewDecoder(req.Body).Decode(obj)
+}
+
+type xmlBinding struct{}
+
+func (xmlBinding) Name() string { return "xml" }
+
+func (xmlBinding) Bind(req *http.Request, obj any) error {
+	if req == nil || req.Body == nil || req.Body == http.NoBody {
+		return ErrEmptyBody
+	}
+	return xml.NewDecoder(req.Body).Decode(obj)
+}
+
+// NewFormBinding 返回解析 multipart 表单时最多在内存中保存 maxMemory 字节的表单绑定器,
+// 用于不经过 Context 直接绑定 *http.Request 的场景; 经过 Context 绑定时使用 Engine.MaxMultipartMemory
+func NewFormBinding(maxMemory int64) Binding {
+	return formBinding{maxMemory: maxMemory}
+}
+
+// NewMultipartBinding 同 NewFormBinding, 请求必须是 multipart 表单
+func NewMultipartBinding(maxMemory int64) Binding {
+	return multipartBinding{maxMemory: maxMemory}
+}
+
+// multipartMemory 返回绑定器解析 multipart 表单时使用的内存上限
+func multipartMemory(maxMemory int64) int64 {
+	if maxMemory > 0 {
+		return maxMemory
+	}
+	return defaultMultipartMemory
+}
+
+// formBinding 绑定查询参数和表单(含 multipart 表单), 使用 form 标签
+type formBinding struct {
+	maxMemory int64 // 0 表示 defaultMultipartMemory
+}
+
+func (formBinding) Name() string { return "form" }
+
+func (b formBinding) Bind(req *http.Request, obj any) error {
+	// 非 multipart 请求时 ParseMultipartForm 返回 ErrNotMultipart 而丢弃 ParseForm 的错误, 先单独解析
+	if err := req.ParseForm(); err != nil {
+		return err
+	}
+	if err := req.ParseMultipartForm(multipartMemory(b.maxMemory)); err != nil && !errors.Is(err, http.ErrNotMultipart) {
+		return err
+	}
+	return mapForm(obj, req.Form, "form")
+}
+
+type multipartBinding struct {
+	maxMemory int64 // 0 表示 defaultMultipartMemory
+}
+
+func (multipartBinding) Name() string { return "multipart/form-data" }
+
+func (b multipartBinding) Bind(req *http.Request, obj any) error {
+	if err := req.ParseMultipartForm(multipartMemory(b.maxMemory)); err != nil {
+		return err
+	}
+	return mapForm(obj, req.Form, "form")
+}
+
+// queryBinding 只绑定URL查询参数, 使用 form 标签
+type queryBinding struct{}
+
+func (queryBinding) Name() string { return "query" }
+
+func (queryBinding) Bind(req *http.Request, obj any) error {
+	return mapForm(obj, req.URL.Query(), "form")
+}
+
+// headerBinding 绑定请求头, 使用 header 标签
+type headerBinding struct{}
+
+func (headerBinding) Name() string { return "header" }
+
+func (headerBinding) Bind(req *http.Request, obj any) error {
+	return mapping(obj, headerSource(req.Header), "header")
+}
+
+// requestContentType 返回请求的内容类型(不含参数)
+func (c *Context) requestContentType() string {
+	if c.Req == nil {
+		return ""
+	}
+	ct, _, err := mime.ParseMediaType(c.Req.Header.Get("Content-Type"))
+	if err != nil {
+		return ""
+	}
+	return ct
+}
+
+// ShouldBind 根据请求方法和 Content-Type 选择绑定器, 解码或校验失败时返回错误
+func (c *Context) ShouldBind(obj any) error {
+	return c.ShouldBindWith(obj, bindingFor(c.Method, c.requestContentType()))
+}
+
+// ShouldBindJSON 绑定 JSON 请求体
+func (c *Context) ShouldBindJSON(obj any) error {
+	return c.ShouldBindWith(obj, BindingJSON)
+}
+
+// ShouldBindXML 绑定 XML 请求体
+func (c *Context) ShouldBindXML(obj any) error {
+	return c.ShouldBindWith(obj, BindingXML)
+}
+
+// ShouldBindQuery 绑定URL查询参数
+func (c *Context) ShouldBindQuery(obj any) error {
+	return c.ShouldBindWith(obj, BindingQuery)
+}
+
+// ShouldBindForm 绑定表单和查询参数
+func (c *Context) ShouldBindForm(obj any) error {
+	return c.ShouldBindWith(obj, BindingForm)
+}
+
+// ShouldBindHeader 绑定请求头
+func (c *Context) ShouldBindHeader(obj any) error {
+	return c.ShouldBindWith(obj, BindingHeader)
+}
+
+// ShouldBindURI 绑定路由参数, 使用 uri 标签
+func (c *Context) ShouldBindURI(obj any) error {
+	values := make(map[string][]string, len(c.Params))
+	for _, p := range c.Params {
+		values[p.Key] = []string{p.Value}
+	}
+	if err := mapForm(obj, values, "uri"); err != nil {
+		return err
+	}
+	return Validate(obj)
+}
+
+// ShouldBindWith 使用指定绑定器解码, 并按 validate 标签校验
+func (c *Context) ShouldBindWith(obj any, b Binding) error {
+	// JSON/XML 绑定读取缓存的请求体, 以便请求体可被多次绑定
+	if b == BindingJSON || b == BindingXML {
+		if _, err := c.GetRawData(); err != nil {
+			return err
+		}
+	}
+	// 按 Engine.MaxMultipartMemory 预先解析 multipart 表单, 绑定器(包括自定义绑定器)不会重复解析
+	if c.requestContentType() == MIMEMultipartPOSTForm {
+		if _, err := c.MultipartForm(); err != nil {
+			return err
+		}
+	}
+	c.limitRequestBody()
+	if err := b.Bind(c.Req, obj); err != nil {
+		return err
+	}
+	return Validate(obj)
+}
+
+// Bind 同 ShouldBind, 失败时返回错误响应(解码失败400, 校验失败422, 请求体过大413, 标签有误500)并终止处理器链
+func (c *Context) Bind(obj any) error {
+	return c.abortOnBindError(c.ShouldBind(obj))
+}
+
+// BindJSON 同 ShouldBindJSON, 失败时返回错误响应并终止处理器链
+func (c *Context) BindJSON(obj any) error {
+	return c.abortOnBindError(c.ShouldBindJSON(obj))
+}
+
+// BindXML 同 ShouldBindXML, 失败时返回错误响应并终止处理器链
+func (c *Context) BindXML(obj any) error {
+	return c.abortOnBindError(c.ShouldBindXML(obj))
+}
+
+// BindQuery 同 ShouldBindQuery, 失败时返回错误响应并终止处理器链
+func (c *Context) BindQuery(obj any) error {
+	return c.abortOnBindError(c.ShouldBindQuery(obj))
+}
+
+// BindForm 同 ShouldBindForm, 失败时返回错误响应并终止处理器链
+func (c *Context) BindForm(obj any) error {
+	return c.abortOnBindError(c.ShouldBindForm(obj))
+}
+
+// BindHeader 同 ShouldBindHeader, 失败时返回错误响应并终止处理器链
+func (c *Context) BindHeader(obj any) error {
+	return c.abortOnBindError(c.ShouldBindHeader(obj))
+}
+
+// BindURI 同 ShouldBindURI, 失败时返回错误响应并终止处理器链
+func (c *Context) BindURI(obj any) error {
+	return c.abortOnBindError(c.ShouldBindURI(obj))
+}
+
+// abortOnBindError 绑定失败时返回错误响应并终止, 校验失败使用422, 请求体过大使用413, validate 标签有误使用500
+func (c *Context) abortOnBindError(err error) error {
+	if err != nil {
+		code := http.StatusBadRequest
+		var verrs ValidationErrors
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &verrs) {
+			code = http.StatusUnprocessableEntity
+		} else if errors.As(err, &tooLarge) {
+			code = http.StatusRequestEntityTooLarge
+		} else if errors.Is(err, ErrInvalidValidateTag) {
+			code = http.StatusInternalServerError
+		}
+		c.Response.Error(code, err)
+		c.Abort()
+	}
+	return err
+}
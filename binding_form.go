@@ -0,0 +1,296 @@
+package gooo
+
+import (
+	"encoding"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/textproto"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	timeType            = reflect.TypeOf(time.Time{})
+	durationType        = reflect.TypeOf(time.Duration(0))
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+// valueSource 绑定时的数据来源
+type valueSource interface {
+	lookup(key string) ([]string, bool)
+}
+
+// formSource 查询参数、表单、路由参数
+type formSource map[string][]string
+
+func (f formSource) lookup(key string) ([]string, bool) {
+	v, ok := f[key]
+	return v, ok && len(v) > 0
+}
+
+// headerSource 请求头, 键名按规范格式查找
+type headerSource http.Header
+
+func (h headerSource) lookup(key string) ([]string, bool) {
+	v, ok := h[textproto.CanonicalMIMEHeaderKey(key)]
+	return v, ok && len(v) > 0
+}
+
+// mapForm 将键值表按 tag 标签绑定到结构体
+func mapForm(obj any, values map[string][]string, tag string) error {
+	return mapping(obj, formSource(values), tag)
+}
+
+// mapping 将数据来源按 tag 标签绑定到 obj 指向的结构体.
+// 字段名取标签中逗号前的部分, 未设置标签时使用字段名, "-" 表示忽略.
+// 未设置标签的嵌套结构体与外层共用键名, 设置了标签的嵌套结构体使用 "标签.子字段" 作为键名.
+// 时间字段通过 time_format 标签指定格式(默认 RFC3339, 也可为 unix / unixmilli / unixnano),
+// time_location 标签指定时区.
+func mapping(obj any, src valueSource, tag string) error {
+	rv := reflect.ValueOf(obj)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() {
+		return errors.New("binding: obj must be a non-nil pointer")
+	}
+	rv = rv.Elem()
+	if rv.Kind() != reflect.Struct {
+		return fmt.Errorf("binding: obj must point to a struct, got %s", rv.Kind())
+	}
+	_, err := mapStruct(rv, src, tag, "")
+	return err
+}
+
+// mapStruct 绑定结构体字段, 返回是否有字段被赋值
+func mapStruct(rv reflect.Value, src valueSource, tag, prefix string) (bool, error) {
+	rt := rv.Type()
+	set := false
+	for i := 0; i < rt.NumField(); i++ {
+		field := rt.Field(i)
+		if !field.IsExported() && !field.Anonymous {
+			continue
+		}
+		name, _, _ := strings.Cut(field.Tag.Get(tag), ",")
+		if name == "-" {
+			continue
+		}
+		fv := rv.Field(i)
+
+		if isNestedStruct(field.Type) {
+			// 未导出的嵌入指针无法赋值, 与 encoding/json 一样跳过;
+			// 未导出的嵌入结构体值仍可绑定其导出字段
+			if fv.Kind() == reflect.Pointer && !fv.CanSet() {
+				continue
+			}
+			childPrefix := prefix
+			if name != "" {
+				childPrefix = prefix + name + "."
+			}
+			ok, err := mapNested(fv, src, tag, childPrefix)
+			if err != nil {
+				return set, err
+			}
+			set = set || ok
+			continue
+		}
+
+		if !fv.CanSet() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		values, ok := src.lookup(prefix + name)
+		if !ok {
+			continue
+		}
+		if err := setField(fv, field, values); err != nil {
+			return set, fmt.Errorf("binding: field %s: %w", field.Name, err)
+		}
+		set = true
+	}
+	return set, nil
+}
+
+// mapNested 绑定嵌套结构体, 指针类型仅在有字段被赋值时才分配
+func mapNested(fv reflect.Value, src valueSource, tag, prefix string) (bool, error) {
+	if fv.Kind() != reflect.Pointer {
+		return mapStruct(fv, src, tag, prefix)
+	}
+	elem := reflect.New(fv.Type().Elem())
+	ok, err := mapNested(elem.Elem(), src, tag, prefix)
+	if ok && err == nil {
+		fv.Set(elem)
+	}
+	return ok, err
+}
+
+// isNestedStruct 判断字段是否按嵌套结构体处理(时间和实现了 TextUnmarshaler 的类型除外)
+func isNestedStruct(t reflect.Type) bool {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct || t == timeType {
+		return false
+	}
+	return !reflect.PointerTo(t).Implements(textUnmarshalerType)
+}
+
+// setField 为字段赋值, 切片和数组使用全部值, 其他类型使用第一个值
+func setField(fv reflect.Value, field reflect.StructField, values []string) error {
+	switch fv.Kind() {
+	case reflect.Slice:
+		if fv.Type().Elem().Kind() == reflect.Uint8 {
+			return setValue(fv, values[0], field)
+		}
+		slice := reflect.MakeSlice(fv.Type(), len(values), len(values))
+		for i, s := range values {
+			if err := setValue(slice.Index(i), s, field); err != nil {
+				return err
+			}
+		}
+		fv.Set(slice)
+		return nil
+	case reflect.Array:
+		if len(values) != fv.Len() {
+			return fmt.Errorf("expected %d values, got %d", fv.Len(), len(values))
+		}
+		for i, s := range values {
+			if err := setValue(fv.Index(i), s, field); err != nil {
+				return err
+			}
+		}
+		return nil
+	default:
+		return setValue(fv, values[0], field)
+	}
+}
+
+// setValue 将字符串转换为目标类型并赋值
+func setValue(v reflect.Value, s string, field reflect.StructField) error {
+	if v.Kind() == reflect.Pointer {
+		elem := reflect.New(v.Type().Elem())
+		if err := setValue(elem.Elem(), s, field); err != nil {
+			return err
+		}
+		v.Set(elem)
+		return nil
+	}
+
+	switch v.Type() {
+	case timeType:
+		return setTime(v, s, field)
+	case durationType:
+		if s == "" {
+			v.SetInt(0)
+			return nil
+		}
+		d, err := time.ParseDuration(s)
+		if err != nil {
+			return err
+		}
+		v.SetInt(int64(d))
+		return nil
+	}
+	if v.CanAddr() && v.Addr().Type().Implements(textUnmarshalerType) {
+		return v.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(s))
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(s)
+	case reflect.Bool:
+		if s == "" {
+			v.SetBool(false)
+			return nil
+		}
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return err
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if s == "" {
+			v.SetInt(0)
+			return nil
+		}
+		n, err := strconv.ParseInt(s, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if s == "" {
+			v.SetUint(0)
+			return nil
+		}
+		n, err := strconv.ParseUint(s, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		if s == "" {
+			v.SetFloat(0)
+			return nil
+		}
+		f, err := strconv.ParseFloat(s, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetFloat(f)
+	case reflect.Slice:
+		// []byte
+		v.SetBytes([]byte(s))
+	default:
+		return fmt.Errorf("unsupported type %s", v.Type())
+	}
+	return nil
+}
+
+// setTime 按 time_format / time_location 标签解析时间
+func setTime(v reflect.Value, s string, field reflect.StructField) error {
+	if s == "" {
+		v.Set(reflect.ValueOf(time.Time{}))
+		return nil
+	}
+
+	loc := time.Local
+	if name := field.Tag.Get("time_location"); name != "" {
+		l, err := time.LoadLocation(name)
+		if err != nil {
+			return err
+		}
+		loc = l
+	}
+
+	format := field.Tag.Get("time_format")
+	switch format {
+	case "unix", "unixmilli", "unixnano":
+		n, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			return err
+		}
+		var t time.Time
+		switch format {
+		case "unix":
+			t = time.Unix(n, 0)
+		case "unixmilli":
+			t = time.UnixMilli(n)
+		default:
+			t = time.Unix(0, n)
+		}
+		v.Set(reflect.ValueOf(t.In(loc)))
+		return nil
+	case "":
+		format = time.RFC3339
+	}
+
+	t, err := time.ParseInLocation(format, s, loc)
+	if err != nil {
+		return err
+	}
+	v.Set(reflect.ValueOf(t))
+	return nil
+}
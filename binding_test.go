@@ -0,0 +1,289 @@
+package gooo
+
+import (
+	"bytes"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+type bindAddress struct {
+	City string `json:"city" form:"city"`
+	Zip  string `json:"zip" form:"zip"`
+}
+
+type bindPagination struct {
+	Page int `form:"page"`
+	Size int `form:"size"`
+}
+
+type bindUser struct {
+	bindPagination
+	Name     string        `json:"name" form:"name"`
+	Age      int           `json:"age" form:"age"`
+	Admin    bool          `json:"admin" form:"admin"`
+	Tags     []string      `json:"tags" form:"tags"`
+	Scores   []float64     `json:"scores" form:"score"`
+	Birthday time.Time     `json:"birthday" form:"birthday" time_format:"2006-01-02" time_location:"UTC"`
+	Created  time.Time     `form:"created" time_format:"unix"`
+	Timeout  time.Duration `form:"timeout"`
+	Address  bindAddress   `json:"address" form:"addr"`
+	Backup   *bindAddress  `form:"backup"`
+	Ignored  string        `form:"-"`
+	Nickname *string       `form:"nick"`
+}
+
+func newBindContext(req *http.Request) *Context {
+	return newContext(httptest.NewRecorder(), req)
+}
+
+func TestBindQuery(t *testing.T) {
+	query := url.Values{
+		"name":      {"alice"},
+		"age":       {"30"},
+		"admin":     {"true"},
+		"tags":      {"a", "b"},
+		"score":     {"1.5", "2"},
+		"birthday":  {"1990-05-17"},
+		"created":   {"1700000000"},
+		"timeout":   {"1m30s"},
+		"addr.city": {"Paris"},
+		"addr.zip":  {"75001"},
+		"page":      {"2"},
+		"size":      {"20"},
+		"Ignored":   {"x"},
+		"-":         {"x"},
+		"nick":      {"al"},
+	}
+	req := httptest.NewRequest("GET", "/?"+query.Encode(), nil)
+	c := newBindContext(req)
+
+	var u bindUser
+	if err := c.BindQuery(&u); err != nil {
+		t.Fatalf("BindQuery failed: %v", err)
+	}
+
+	if u.Name != "alice" || u.Age != 30 || !u.Admin {
+		t.Errorf("Unexpected basic fields: %+v", u)
+	}
+	if len(u.Tags) != 2 || u.Tags[1] != "b" || len(u.Scores) != 2 || u.Scores[0] != 1.5 {
+		t.Errorf("Unexpected slices: %v %v", u.Tags, u.Scores)
+	}
+	if !u.Birthday.Equal(time.Date(1990, 5, 17, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Unexpected birthday: %v", u.Birthday)
+	}
+	if u.Created.Unix() != 1700000000 || u.Timeout != 90*time.Second {
+		t.Errorf("Unexpected created/timeout: %v %v", u.Created, u.Timeout)
+	}
+	if u.Address.City != "Paris" || u.Address.Zip != "75001" {
+		t.Errorf("Unexpected nested struct: %+v", u.Address)
+	}
+	if u.Backup != nil {
+		t.Errorf("Nested pointer without values should stay nil, got %+v", u.Backup)
+	}
+	if u.Page != 2 || u.Size != 20 {
+		t.Errorf("Unexpected embedded struct: %+v", u.bindPagination)
+	}
+	if u.Ignored != "" {
+		t.Errorf("Ignored field should not be bound, got %s", u.Ignored)
+	}
+	if u.Nickname == nil || *u.Nickname != "al" {
+		t.Errorf("Unexpected pointer field: %v", u.Nickname)
+	}
+}
+
+func TestBindJSON(t *testing.T) {
+	body := `{"name":"bob","age":5,"tags":["x"],"address":{"city":"Rome"},"birthday":"2000-01-02T00:00:00Z"}`
+	req := httptest.NewRequest("POST", "/", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json; charset=utf-8")
+	c := newBindContext(req)
+
+	var u bindUser
+	if err := c.Bind(&u); err != nil {
+		t.Fatalf("Bind failed: %v", err)
+	}
+	if u.Name != "bob" || u.Age != 5 || u.Address.City != "Rome" || u.Birthday.Year() != 2000 {
+		t.Errorf("Unexpected result: %+v", u)
+	}
+}
+
+func TestBindXML(t *testing.T) {
+	type item struct {
+		Name  string `xml:"name"`
+		Count int    `xml:"count"`
+	}
+	req := httptest.NewRequest("POST", "/", strings.NewReader(`<item><name>pen</name><count>3</count></item>`))
+	req.Header.Set("Content-Type", "application/xml")
+	c := newBindContext(req)
+
+	var it item
+	if err := c.Bind(&it); err != nil {
+		t.Fatalf("Bind failed: %v", err)
+	}
+	if it.Name != "pen" || it.Count != 3 {
+		t.Errorf("Unexpected result: %+v", it)
+	}
+}
+
+func TestBindForm(t *testing.T) {
+	form := url.Values{"name": {"carol"}, "age": {"41"}, "tags": {"t1", "t2"}}
+	req := httptest.NewRequest("POST", "/?page=3", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", MIMEPOSTForm)
+	c := newBindContext(req)
+
+	var u bindUser
+	if err := c.Bind(&u); err != nil {
+		t.Fatalf("Bind failed: %v", err)
+	}
+	if u.Name != "carol" || u.Age != 41 || len(u.Tags) != 2 || u.Page != 3 {
+		t.Errorf("Unexpected result: %+v", u)
+	}
+}
+
+func TestBindMultipart(t *testing.T) {
+	var buf bytes.Buffer
+	mw := multipart.NewWriter(&buf)
+	mw.WriteField("name", "dave")
+	mw.WriteField("addr.city", "Oslo")
+	mw.Close()
+
+	req := httptest.NewRequest("POST", "/", &buf)
+	req.Header.Set("Content-Type", mw.FormDataContentType())
+	c := newBindContext(req)
+
+	var u bindUser
+	if err := c.Bind(&u); err != nil {
+		t.Fatalf("Bind failed: %v", err)
+	}
+	if u.Name != "dave" || u.Address.City != "Oslo" {
+		t.Errorf("Unexpected result: %+v", u)
+	}
+}
+
+func TestNewMultipartBinding(t *testing.T) {
+	var buf bytes.Buffer
+	mw := multipart.NewWriter(&buf)
+	mw.WriteField("name", "erin")
+	fw, _ := mw.CreateFormFile("file", "a.txt")
+	fw.Write(bytes.Repeat([]byte("x"), 4<<10))
+	mw.Close()
+
+	req := httptest.NewRequest("POST", "/", &buf)
+	req.Header.Set("Content-Type", mw.FormDataContentType())
+
+	// 不经过 Context 直接绑定, 超出 maxMemory 的文件写入临时文件
+	var u bindUser
+	if err := NewMultipartBinding(1<<10).Bind(req, &u); err != nil {
+		t.Fatalf("Bind failed: %v", err)
+	}
+	defer req.MultipartForm.RemoveAll()
+	if u.Name != "erin" {
+		t.Errorf("Unexpected result: %+v", u)
+	}
+	f, err := req.MultipartForm.File["file"][0].Open()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	if _, ok := f.(*os.File); !ok {
+		t.Errorf("Expected file beyond maxMemory to be stored on disk, got %T", f)
+	}
+}
+
+func TestBindHeaderAndURI(t *testing.T) {
+	type headers struct {
+		RequestID string   `header:"x-request-id"`
+		Accept    []string `header:"Accept"`
+		Limit     int      `header:"X-Rate-Limit"`
+	}
+	type uri struct {
+		ID   int    `uri:"id"`
+		Slug string `uri:"slug"`
+	}
+
+	engine := New()
+	engine.GET("/posts/:id/:slug", func(c *Context) {
+		var h headers
+		var u uri
+		if err := c.BindHeader(&h); err != nil {
+			return
+		}
+		if err := c.BindURI(&u); err != nil {
+			return
+		}
+		c.String(http.StatusOK, "%s %v %d %d %s", h.RequestID, h.Accept, h.Limit, u.ID, u.Slug)
+	})
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/posts/12/hello", nil)
+	req.Header.Set("X-Request-Id", "abc")
+	req.Header.Add("Accept", "text/html")
+	req.Header.Add("Accept", "application/json")
+	req.Header.Set("X-Rate-Limit", "100")
+	engine.ServeHTTP(w, req)
+
+	if body := w.Body.String(); body != "abc [text/html application/json] 100 12 hello" {
+		t.Errorf("Unexpected body: %s", body)
+	}
+
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, httptest.NewRequest("GET", "/posts/abc/hello", nil))
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status 400 for invalid uri param, got %d", w.Code)
+	}
+}
+
+func TestBindErrors(t *testing.T) {
+	c := newBindContext(httptest.NewRequest("POST", "/", nil))
+	var u bindUser
+	if err := c.ShouldBindJSON(&u); err != ErrEmptyBody {
+		t.Errorf("Expected ErrEmptyBody, got %v", err)
+	}
+	if err := c.ShouldBindQuery(u); err == nil {
+		t.Error("Binding into a non-pointer should fail")
+	}
+	var n int
+	if err := c.ShouldBindQuery(&n); err == nil {
+		t.Error("Binding into a non-struct should fail")
+	}
+
+	c = newBindContext(httptest.NewRequest("GET", "/?age=old", nil))
+	if err := c.ShouldBind(&u); err == nil || !strings.Contains(err.Error(), "Age") {
+		t.Errorf("Expected error mentioning field Age, got %v", err)
+	}
+}
+
+type bindInner struct {
+	Page int `form:"page"`
+}
+
+type bindLevel int
+
+func TestBindUnexportedEmbedded(t *testing.T) {
+	type withPointer struct {
+		*bindInner
+		Age int `form:"age"`
+	}
+	type withValue struct {
+		bindInner
+		bindLevel
+		Age int `form:"age"`
+	}
+
+	c := newBindContext(httptest.NewRequest("GET", "/?page=3&age=20&bindLevel=1", nil))
+
+	// 未导出的嵌入指针和非结构体字段被跳过, 不会 panic
+	var p withPointer
+	if err := c.ShouldBindQuery(&p); err != nil || p.Age != 20 || p.bindInner != nil {
+		t.Errorf("Unexpected result %+v: %v", p, err)
+	}
+	var v withValue
+	if err := c.ShouldBindQuery(&v); err != nil || v.Age != 20 || v.Page != 3 || v.bindLevel != 0 {
+		t.Errorf("Unexpected result %+v: %v", v, err)
+	}
+}
@@ -0,0 +1,146 @@
+package gooo
+
+import (
+	"fmt"
+	"net"
+	"strings"
+)
+
+// SetTrustedProxies 设置受信任的代理地址, 元素可为 IP 或 CIDR (如 10.0.0.0/8).
+// 只有直接连接方位于列表中时, ClientIP 才会读取 Forwarded / X-Forwarded-For / X-Real-IP 头.
+// 传入空列表表示不信任任何代理(默认).
+func (engine *Engine) SetTrustedProxies(proxies []string) error {
+	cidrs := make([]*net.IPNet, 0, len(proxies))
+	for _, proxy := range proxies {
+		proxy = strings.TrimSpace(proxy)
+		if !strings.Contains(proxy, "/") {
+			ip := net.ParseIP(proxy)
+			if ip == nil {
+				return fmt.Errorf("invalid trusted proxy %q", proxy)
+			}
+			bits := 8 * net.IPv6len
+			if ip4 := ip.To4(); ip4 != nil {
+				ip, bits = ip4, 8*net.IPv4len
+			}
+			cidrs = append(cidrs, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			continue
+		}
+		_, cidr, err := net.ParseCIDR(proxy)
+		if err != nil {
+			return fmt.Errorf("invalid trusted proxy %q: %w", proxy, err)
+		}
+		cidrs = append(cidrs, cidr)
+	}
+	engine.trustedCIDRs = cidrs
+	return nil
+}
+
+// isTrustedProxy 判断 IP 是否属于受信任的代理
+func (engine *Engine) isTrustedProxy(ip net.IP) bool {
+	for _, cidr := range engine.trustedCIDRs {
+		if cidr.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// RemoteIP 返回直接连接方的 IP (来自 Req.RemoteAddr)
+func (c *Context) RemoteIP() string {
+	if c.Req == nil {
+		return ""
+	}
+	host, _, err := net.SplitHostPort(strings.TrimSpace(c.Req.RemoteAddr))
+	if err != nil {
+		return strings.TrimSpace(c.Req.RemoteAddr)
+	}
+	return host
+}
+
+// ClientIP 返回客户端真实 IP.
+// 直接连接方是受信任的代理时, 依次读取 Forwarded (RFC 7239)、X-Forwarded-For、X-Real-IP,
+// 在代理链中从右向左跳过受信任的代理, 返回第一个不受信任的地址; 否则返回 RemoteIP.
+func (c *Context) ClientIP() string {
+	remoteIP := c.RemoteIP()
+	if c.engine == nil {
+		return remoteIP
+	}
+	ip := net.ParseIP(remoteIP)
+	if ip == nil || !c.engine.isTrustedProxy(ip) {
+		return remoteIP
+	}
+
+	if chain, ok := parseForwarded(c.Req.Header.Values("Forwarded")); ok {
+		if client, ok := c.engine.clientFromChain(chain); ok {
+			return client
+		}
+	}
+	if chain, ok := splitForwardedFor(c.Req.Header.Values("X-Forwarded-For")); ok {
+		if client, ok := c.engine.clientFromChain(chain); ok {
+			return client
+		}
+	}
+	if realIP := net.ParseIP(strings.TrimSpace(c.Req.Header.Get("X-Real-IP"))); realIP != nil {
+		return realIP.String()
+	}
+	return remoteIP
+}
+
+// clientFromChain 从右向左跳过受信任的代理, 返回第一个不受信任的地址;
+// 全部受信任时返回最左侧地址. 链中出现无效地址时返回 false.
+func (engine *Engine) clientFromChain(chain []string) (string, bool) {
+	for i := len(chain) - 1; i >= 0; i-- {
+		ip := net.ParseIP(chain[i])
+		if ip == nil {
+			return "", false
+		}
+		if i == 0 || !engine.isTrustedProxy(ip) {
+			return ip.String(), true
+		}
+	}
+	return "", false
+}
+
+// splitForwardedFor 拆分 X-Forwarded-For 头, 多个头按出现顺序拼接
+func splitForwardedFor(headers []string) ([]string, bool) {
+	var chain []string
+	for _, header := range headers {
+		for _, item := range strings.Split(header, ",") {
+			chain = append(chain, strings.TrimSpace(item))
+		}
+	}
+	return chain, len(chain) > 0
+}
+
+// parseForwarded 解析 RFC 7239 Forwarded 头中的 for 参数, 如
+// for=192.0.2.60;proto=http, for="[2001:db8::17]:4711"
+func parseForwarded(headers []string) ([]string, bool) {
+	var chain []string
+	for _, header := range headers {
+		for _, element := range strings.Split(header, ",") {
+			for _, pair := range strings.Split(element, ";") {
+				key, value, ok := strings.Cut(strings.TrimSpace(pair), "=")
+				if !ok || !strings.EqualFold(key, "for") {
+					continue
+				}
+				chain = append(chain, forwardedNode(value))
+			}
+		}
+	}
+	return chain, len(chain) > 0
+}
+
+// forwardedNode 去掉 Forwarded 节点的引号、IPv6 方括号和端口
+func forwardedNode(value string) string {
+	value = strings.Trim(strings.TrimSpace(value), `"`)
+	if strings.HasPrefix(value, "[") {
+		if end := strings.IndexByte(value, ']'); end > 0 {
+			return value[1:end]
+		}
+		return value
+	}
+	if host, _, err := net.SplitHostPort(value); err == nil {
+		return host
+	}
+	return value
+}
@@ -0,0 +1,105 @@
+package gooo
+
+import (
+	"bytes"
+	"log"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestSetTrustedProxies(t *testing.T) {
+	engine := New()
+	if err := engine.SetTrustedProxies([]string{"10.0.0.0/8", "192.168.1.1", "::1"}); err != nil {
+		t.Fatal(err)
+	}
+	for _, proxy := range []string{"not-an-ip", "10.0.0.0/33"} {
+		if err := engine.SetTrustedProxies([]string{proxy}); err == nil {
+			t.Errorf("Expected error for %q", proxy)
+		}
+	}
+}
+
+func TestClientIP(t *testing.T) {
+	engine := New()
+	if err := engine.SetTrustedProxies([]string{"10.0.0.0/8", "::1"}); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name       string
+		remoteAddr string
+		headers    map[string]string
+		expected   string
+	}{
+		{"no headers", "203.0.113.5:1234", nil, "203.0.113.5"},
+		{"untrusted peer ignores headers", "203.0.113.5:1234",
+			map[string]string{"X-Forwarded-For": "1.2.3.4", "X-Real-IP": "1.2.3.4"}, "203.0.113.5"},
+		{"x-forwarded-for", "10.0.0.1:80",
+			map[string]string{"X-Forwarded-For": "1.2.3.4"}, "1.2.3.4"},
+		{"skip trusted hops", "10.0.0.1:80",
+			map[string]string{"X-Forwarded-For": "6.6.6.6, 1.2.3.4, 10.0.0.2"}, "1.2.3.4"},
+		{"all hops trusted", "10.0.0.1:80",
+			map[string]string{"X-Forwarded-For": "10.0.0.3, 10.0.0.2"}, "10.0.0.3"},
+		{"invalid xff falls back to x-real-ip", "10.0.0.1:80",
+			map[string]string{"X-Forwarded-For": "garbage", "X-Real-IP": "5.6.7.8"}, "5.6.7.8"},
+		{"x-real-ip", "[::1]:80",
+			map[string]string{"X-Real-IP": "2001:db8::1"}, "2001:db8::1"},
+		{"forwarded", "10.0.0.1:80",
+			map[string]string{"Forwarded": `for=192.0.2.60;proto=http;by=10.0.0.1`}, "192.0.2.60"},
+		{"forwarded ipv6 with port", "10.0.0.1:80",
+			map[string]string{"Forwarded": `for="[2001:db8:cafe::17]:4711", for=10.0.0.9`}, "2001:db8:cafe::17"},
+		{"forwarded preferred over xff", "10.0.0.1:80",
+			map[string]string{"Forwarded": "for=192.0.2.60", "X-Forwarded-For": "1.2.3.4"}, "192.0.2.60"},
+		{"obfuscated forwarded falls back", "10.0.0.1:80",
+			map[string]string{"Forwarded": "for=_hidden", "X-Forwarded-For": "1.2.3.4"}, "1.2.3.4"},
+		{"remote addr without port", "1.2.3.4", nil, "1.2.3.4"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", "/", nil)
+			req.RemoteAddr = tt.remoteAddr
+			for k, v := range tt.headers {
+				req.Header.Set(k, v)
+			}
+			c := engine.allocateContext()
+			c.reset(httptest.NewRecorder(), req)
+			if ip := c.ClientIP(); ip != tt.expected {
+				t.Errorf("Expected %s, got %s", tt.expected, ip)
+			}
+		})
+	}
+}
+
+func TestClientIP_NoTrustedProxies(t *testing.T) {
+	engine := New()
+	req := httptest.NewRequest("GET", "/", nil)
+	req.RemoteAddr = "10.0.0.1:80"
+	req.Header.Set("X-Forwarded-For", "1.2.3.4")
+	c := engine.allocateContext()
+	c.reset(httptest.NewRecorder(), req)
+	if ip := c.ClientIP(); ip != "10.0.0.1" {
+		t.Errorf("Headers should be ignored by default, got %s", ip)
+	}
+}
+
+func TestLogger_ClientIP(t *testing.T) {
+	var buf bytes.Buffer
+	log.SetOutput(&buf)
+	defer log.SetOutput(os.Stderr)
+
+	engine := New()
+	engine.SetTrustedProxies([]string{"10.0.0.0/8"})
+	engine.Use(Logger())
+	engine.GET("/ping", func(c *Context) {})
+
+	req := httptest.NewRequest("GET", "/ping", nil)
+	req.RemoteAddr = "10.0.0.1:80"
+	req.Header.Set("X-Forwarded-For", "1.2.3.4")
+	engine.ServeHTTP(httptest.NewRecorder(), req)
+
+	if !strings.Contains(buf.String(), "1.2.3.4 /ping") {
+		t.Errorf("Logger should print client IP, got %q", buf.String())
+	}
+}
@@ -1,25 +1,64 @@
 package gooo
 
 import (
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
 	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
 	"time"
 )
 
 // H 自定义类型表示键值对数据
 type H map[string]interface{}
 
+// MarshalXML 将 H 编码为 <map><键>值</键></map>, 键按字母顺序输出
+func (h H) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	start.Name = xml.Name{Local: "map"}
+	if err := e.EncodeToken(start); err != nil {
+		return err
+	}
+	keys := make([]string, 0, len(h))
+	for k := range h {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		if err := e.EncodeElement(h[k], xml.StartElement{Name: xml.Name{Local: k}}); err != nil {
+			return err
+		}
+	}
+	return e.EncodeToken(start.End())
+}
+
 // Context 上下文结构体
 type Context struct {
 	// 原始对象
-	Writer   http.ResponseWriter
+	Writer   http.ResponseWriter // 经由 Engine 处理的请求为 gooo 的 ResponseWriter
 	Req      *http.Request
-	Response *Response // 新增响应模块引用
+	Response *Response      // 新增响应模块引用
+	response Response       // Response 指向的内存, 随 Context 复用
+	writer   responseWriter // Writer 指向的内存, 随 Context 复用
 	// 请求信息
 	Path   string
 	Method string
-	Params map[string]string // 路由参数
+	Params Params // 路由参数(含 Host 参数), 底层数组随 Context 复用
 	// 中间件数据
-	keys     map[string]any
+	keys map[string]any
+	// 请求数据缓存, 首次访问时解析
+	queryCache  url.Values
+	formCache   url.Values
+	jsonCache   map[string]json.RawMessage
+	body        []byte
+	bodyCached  bool
+	bodyLimited bool // Req.Body 是否已按 Engine 的限制包装
+
 	handlers []HandlerFunc // 中间件链
 	index    int           // 当前执行的中间件索引
 	aborted  bool          // 是否已终止
@@ -31,15 +70,60 @@ type Context struct {
 
 // 构造函数
 func newContext(w http.ResponseWriter, req *http.Request) *Context {
-	return &Context{
-		Writer:   w,
-		Req:      req,
-		Response: &Response{Writer: w}, // 初始化响应模块
-		Path:     req.URL.Path,
-		Method:   req.Method,
-		Params:   make(map[string]string),
-		keys:     make(map[string]any),
+	c := &Context{}
+	c.reset(w, req)
+	return c
+}
+
+// reset 重置 Context 以便从对象池中复用, 保留 Params 和 Response 的底层内存
+func (c *Context) reset(w http.ResponseWriter, req *http.Request) {
+	c.writer.reset(w)
+	c.Writer = &c.writer
+	c.response = Response{Writer: &c.writer}
+	c.Req = req
+	c.Response = &c.response
+	c.Path = req.URL.Path
+	c.Method = req.Method
+	c.Params = c.Params[:0]
+	c.keys = nil
+	c.queryCache = nil
+	c.formCache = nil
+	c.jsonCache = nil
+	c.body = nil
+	c.bodyCached = false
+	c.bodyLimited = false
+	c.handlers = nil
+	c.index = -1
+	c.aborted = false
+	c.Session = nil
+	c.SessionID = ""
+}
+
+// Copy 返回当前 Context 的副本, 用于在请求结束后交给其他 goroutine 使用.
+// 原 Context 在请求结束后会被放回对象池复用, 因此不能直接跨 goroutine 持有.
+// 副本不持有 ResponseWriter, 不能用于写响应, 也不会继续执行处理器链.
+func (c *Context) Copy() *Context {
+	cp := &Context{
+		Req:       c.Req,
+		Path:      c.Path,
+		Method:    c.Method,
+		engine:    c.engine,
+		index:     len(c.handlers),
+		aborted:   true,
+		Session:   c.Session,
+		SessionID: c.SessionID,
+	}
+	cp.Response = &cp.response
+	cp.response.StatusCode = c.GetStatusCode()
+	cp.Params = make(Params, len(c.Params))
+	copy(cp.Params, c.Params)
+	if c.keys != nil {
+		cp.keys = make(map[string]any, len(c.keys))
+		for k, v := range c.keys {
+			cp.keys[k] = v
+		}
 	}
+	return cp
 }
 
 // Set 存储中间件数据
@@ -65,32 +149,109 @@ func (c *Context) MustGet(key string) any {
 }
 
 // 参数获取方法
+// PostForm 获取表单参数, 与 Req.FormValue 相同: 优先取请求体(urlencoded 或 multipart), 其次取查询参数.
+// 只读取请求体时使用 GetPostForm / DefaultPostForm
 func (c *Context) PostForm(key string) string {
-	return c.Req.FormValue(key)
+	if value, ok := c.GetPostForm(key); ok {
+		return value
+	}
+	return c.Query(key)
 }
 
+// Query 获取查询参数, 多个值时返回第一个
 func (c *Context) Query(key string) string {
-	return c.Req.URL.Query().Get(key)
+	value, _ := c.GetQuery(key)
+	return value
 }
 
 // 获取路由参数
 func (c *Context) Param(key string) string {
-	return c.Params[key]
+	return c.Params.ByName(key)
+}
+
+// Param 单个路由参数
+type Param struct {
+	Key   string
+	Value string
+}
+
+// Params 路由参数列表, 按在路径中出现的顺序排列
+type Params []Param
+
+// Get 返回第一个名称匹配的参数值
+func (ps Params) Get(name string) (string, bool) {
+	for _, p := range ps {
+		if p.Key == name {
+			return p.Value, true
+		}
+	}
+	return "", false
+}
+
+// ByName 返回参数值, 不存在时返回空串
+func (ps Params) ByName(name string) string {
+	v, _ := ps.Get(name)
+	return v
+}
+
+// ErrParamNotFound 路由参数不存在
+var ErrParamNotFound = errors.New("param not found")
+
+// ParamInt 获取整型路由参数
+func (c *Context) ParamInt(key string) (int, error) {
+	value, ok := c.Params.Get(key)
+	if !ok {
+		return 0, fmt.Errorf("param %q: %w", key, ErrParamNotFound)
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("param %q: %w", key, err)
+	}
+	return n, nil
+}
+
+// ParamInt64 获取64位整型路由参数
+func (c *Context) ParamInt64(key string) (int64, error) {
+	value, ok := c.Params.Get(key)
+	if !ok {
+		return 0, fmt.Errorf("param %q: %w", key, ErrParamNotFound)
+	}
+	n, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("param %q: %w", key, err)
+	}
+	return n, nil
 }
 
-// 获取请求参数(查询参数或表单参数)
+// ParamUUID 获取UUID路由参数, 返回小写的标准格式
+func (c *Context) ParamUUID(key string) (string, error) {
+	value, ok := c.Params.Get(key)
+	if !ok {
+		return "", fmt.Errorf("param %q: %w", key, ErrParamNotFound)
+	}
+	if !uuidPattern.MatchString(value) {
+		return "", fmt.Errorf("param %q: invalid uuid %q", key, value)
+	}
+	return strings.ToLower(value), nil
+}
+
+var uuidPattern = regexp.MustCompile("^" + paramConstraints["uuid"] + "$")
+
+// GetParam 统一获取请求参数, 与请求方法无关, 依次查找:
+// JSON 请求体的顶层字段、请求体中的表单参数、查询参数.
+// JSON 中的字符串返回其内容, null 返回空串, 数字、布尔值、对象和数组返回原始 JSON 文本.
 func (c *Context) GetParam(key string) string {
 	if c.Req == nil {
 		return ""
 	}
-	switch c.Method {
-	case http.MethodGet:
-		return c.Query(key)
-	case http.MethodPost, http.MethodPut, http.MethodPatch:
-		return c.PostForm(key)
-	default:
-		return ""
+	if value, ok := c.getJSONParam(key); ok {
+		return value
+	}
+	if value, ok := c.GetPostForm(key); ok {
+		return value
 	}
+	value, _ := c.GetQuery(key)
+	return value
 }
 
 // 设置响应头
@@ -113,8 +274,11 @@ func (c *Context) GetContentType(name string) string {
 	return c.Response.GetContentType(name)
 }
 
-// 获取响应状态码
+// 获取响应状态码, 处理器只写了响应体时为200
 func (c *Context) GetStatusCode() int {
+	if w, ok := c.Response.Writer.(ResponseWriter); ok {
+		return w.Status()
+	}
 	return c.Response.StatusCode
 }
 
@@ -128,11 +292,19 @@ func (c *Context) String(code int, format string, values ...any) {
 	c.Response.String(code, format, values...)
 }
 
+// 设置响应内容 XML
+func (c *Context) XML(code int, obj any) {
+	c.Response.XML(code, obj)
+}
+
 // 设置响应内容 HTML
 func (c *Context) HTML(code int, html string) {
 	c.Response.HTML(code, html)
 }
 
+// Context 实现 context.Context, 可直接传给数据库驱动、HTTP客户端等
+var _ context.Context = (*Context)(nil)
+
 // 1. 添加请求上下文超时控制
 func (c *Context) Deadline() (deadline time.Time, ok bool) {
 	if c.Req != nil && c.Req.Context() != nil {
@@ -141,6 +313,35 @@ func (c *Context) Deadline() (deadline time.Time, ok bool) {
 	return
 }
 
+// Done 返回请求上下文的 Done 通道, 客户端断开或超时时关闭
+func (c *Context) Done() <-chan struct{} {
+	if c.Req == nil {
+		return nil
+	}
+	return c.Req.Context().Done()
+}
+
+// Err 返回请求上下文结束的原因
+func (c *Context) Err() error {
+	if c.Req == nil {
+		return nil
+	}
+	return c.Req.Context().Err()
+}
+
+// Value 字符串键优先从 c.Set 存储的数据中查找, 否则交给请求上下文
+func (c *Context) Value(key any) any {
+	if k, ok := key.(string); ok {
+		if value, exists := c.Get(k); exists {
+			return value
+		}
+	}
+	if c.Req == nil {
+		return nil
+	}
+	return c.Req.Context().Value(key)
+}
+
 // 2. 统一参数获取方法
 func (c *Context) GetParamWithDefault(key string, defaultValue string) string {
 	if val := c.GetParam(key); val != "" {
@@ -1,7 +1,9 @@
 package gooo
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
@@ -231,3 +233,159 @@ func TestContext_SessionOperations(t *testing.T) {
 		t.Error("会话应被销毁")
 	}
 }
+
+func TestContext_TypedParams(t *testing.T) {
+	c := &Context{Params: Params{
+		{Key: "id", Value: "42"},
+		{Key: "bad", Value: "4x2"},
+		{Key: "uuid", Value: "123E4567-E89B-12D3-A456-426614174000"},
+	}}
+
+	if id, err := c.ParamInt("id"); err != nil || id != 42 {
+		t.Errorf("ParamInt: expected 42, got %d (%v)", id, err)
+	}
+	if id, err := c.ParamInt64("id"); err != nil || id != 42 {
+		t.Errorf("ParamInt64: expected 42, got %d (%v)", id, err)
+	}
+	if _, err := c.ParamInt("bad"); err == nil {
+		t.Error("ParamInt should fail on non-numeric value")
+	}
+	if _, err := c.ParamInt("missing"); !errors.Is(err, ErrParamNotFound) {
+		t.Errorf("expected ErrParamNotFound, got %v", err)
+	}
+	if u, err := c.ParamUUID("uuid"); err != nil || u != "123e4567-e89b-12d3-a456-426614174000" {
+		t.Errorf("ParamUUID: unexpected %s (%v)", u, err)
+	}
+	if _, err := c.ParamUUID("id"); err == nil {
+		t.Error("ParamUUID should fail on invalid uuid")
+	}
+}
+
+func TestContext_Copy(t *testing.T) {
+	engine := New()
+	copies := make(chan *Context, 1)
+	engine.GET("/users/:id", func(c *Context) {
+		c.Set("user", "alice")
+		copies <- c.Copy()
+		c.String(http.StatusOK, "ok")
+	})
+	engine.GET("/other/:name", func(c *Context) {
+		c.Set("user", "bob")
+	})
+
+	engine.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/users/1", nil))
+	cp := <-copies
+
+	// 原 Context 放回对象池后被复用, 副本不受影响
+	engine.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/other/x", nil))
+
+	if id := cp.Param("id"); id != "1" {
+		t.Errorf("Expected copied param id '1', got '%s'", id)
+	}
+	if user := cp.MustGet("user"); user != "alice" {
+		t.Errorf("Expected copied key 'alice', got '%v'", user)
+	}
+	if cp.Path != "/users/1" || cp.Method != "GET" {
+		t.Errorf("Unexpected copied request info: %s %s", cp.Method, cp.Path)
+	}
+	if !cp.IsAborted() {
+		t.Error("Copied context should not continue the handler chain")
+	}
+}
+
+func TestContext_Reset(t *testing.T) {
+	engine := New()
+	var seen []string
+	engine.GET("/users/:id", func(c *Context) {
+		c.Set("k", "v")
+		c.Abort()
+	})
+	engine.GET("/static", func(c *Context) {
+		_, exists := c.Get("k")
+		seen = append(seen, c.Param("id"))
+		if exists || c.IsAborted() || len(c.Params) != 0 {
+			t.Errorf("Context state leaked between requests: params=%v exists=%v", c.Params, exists)
+		}
+	})
+
+	for i := 0; i < 3; i++ {
+		engine.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/users/7", nil))
+		engine.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/static", nil))
+	}
+	if len(seen) != 3 {
+		t.Fatalf("Expected 3 requests to /static, got %d", len(seen))
+	}
+}
+
+type ctxKey struct{}
+
+func TestContext_ContextInterface(t *testing.T) {
+	parent := context.WithValue(context.Background(), ctxKey{}, "from-request")
+	parent, cancel := context.WithCancel(parent)
+	req := httptest.NewRequest("GET", "/", nil).WithContext(parent)
+	c := &Context{Req: req}
+	c.Set("user", "alice")
+
+	var ctx context.Context = c
+	if v := ctx.Value("user"); v != "alice" {
+		t.Errorf("Expected Value to fall back to keys, got %v", v)
+	}
+	if v := ctx.Value(ctxKey{}); v != "from-request" {
+		t.Errorf("Expected Value from request context, got %v", v)
+	}
+	if ctx.Err() != nil {
+		t.Errorf("Expected nil Err before cancel, got %v", ctx.Err())
+	}
+
+	cancel()
+	select {
+	case <-ctx.Done():
+	case <-time.After(time.Second):
+		t.Fatal("Done should be closed after cancel")
+	}
+	if !errors.Is(ctx.Err(), context.Canceled) {
+		t.Errorf("Expected context.Canceled, got %v", ctx.Err())
+	}
+
+	empty := &Context{}
+	if empty.Done() != nil || empty.Err() != nil || empty.Value("x") != nil {
+		t.Error("Context without request should behave like context.Background")
+	}
+}
+
+func TestContext_ClientDisconnect(t *testing.T) {
+	engine := New()
+	started := make(chan struct{})
+	result := make(chan error, 1)
+	engine.GET("/slow", func(c *Context) {
+		close(started)
+		select {
+		case <-c.Done():
+			result <- c.Err()
+		case <-time.After(5 * time.Second):
+			result <- nil
+		}
+	})
+
+	server := httptest.NewServer(engine)
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	req, _ := http.NewRequestWithContext(ctx, "GET", server.URL+"/slow", nil)
+	go func() {
+		<-started
+		cancel()
+	}()
+	if _, err := http.DefaultClient.Do(req); err == nil {
+		t.Fatal("Expected client request to be canceled")
+	}
+
+	select {
+	case err := <-result:
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("Expected handler to observe context.Canceled, got %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Handler did not observe client disconnect")
+	}
+}
@@ -0,0 +1,173 @@
+package gooo
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// Cookie 相关错误
+var (
+	ErrCookieSecretNotSet = errors.New("cookie secret not set")
+	ErrInvalidCookie      = errors.New("invalid cookie")
+)
+
+// CookieOptions 设置 Cookie 时的选项
+type CookieOptions struct {
+	Path        string        // 为空时使用 "/"
+	Domain      string        // 为空时只对当前主机有效
+	MaxAge      int           // 秒数, 0 表示会话 Cookie, 负数表示立即删除
+	Secure      bool          // 只通过 HTTPS 发送
+	HttpOnly    bool          // 禁止脚本读取
+	SameSite    http.SameSite // 跨站发送策略
+	Partitioned bool          // CHIPS 分区 Cookie, 要求 Secure
+}
+
+// Cookie 获取请求中的 Cookie, 值经过 URL 解码; 不存在时返回 http.ErrNoCookie
+func (c *Context) Cookie(name string) (string, error) {
+	cookie, err := c.Req.Cookie(name)
+	if err != nil {
+		return "", err
+	}
+	return url.QueryUnescape(cookie.Value)
+}
+
+// SetCookie 设置 Cookie, 值经过 URL 编码
+func (c *Context) SetCookie(name, value string, opts CookieOptions) {
+	c.writeCookie(name, url.QueryEscape(value), opts)
+}
+
+// writeCookie 写入 Set-Cookie 响应头
+func (c *Context) writeCookie(name, value string, opts CookieOptions) {
+	if opts.Path == "" {
+		opts.Path = "/"
+	}
+	cookie := &http.Cookie{
+		Name:     name,
+		Value:    value,
+		Path:     opts.Path,
+		Domain:   opts.Domain,
+		MaxAge:   opts.MaxAge,
+		Secure:   opts.Secure || opts.Partitioned,
+		HttpOnly: opts.HttpOnly,
+		SameSite: opts.SameSite,
+	}
+	v := cookie.String()
+	if v == "" {
+		DebugPrint("无效的 Cookie: %s", name)
+		return
+	}
+	// http.Cookie 在较早的 Go 版本中不支持 Partitioned, 手动追加
+	if opts.Partitioned {
+		v += "; Partitioned"
+	}
+	c.Writer.Header().Add("Set-Cookie", v)
+}
+
+// SetSignedCookie 设置带 HMAC-SHA256 签名的 Cookie, 内容可被客户端读取但无法篡改.
+// 签名密钥由 Engine.CookieSecret 派生, 签名包含 Cookie 名称, 因此不能挪用到其他 Cookie.
+func (c *Context) SetSignedCookie(name, value string, opts CookieOptions) error {
+	key, err := c.cookieKey("sign")
+	if err != nil {
+		return err
+	}
+	payload := base64.RawURLEncoding.EncodeToString([]byte(value))
+	c.writeCookie(name, payload+"."+signCookie(key, name, payload), opts)
+	return nil
+}
+
+// SignedCookie 获取并校验签名 Cookie, 签名不匹配时返回 ErrInvalidCookie
+func (c *Context) SignedCookie(name string) (string, error) {
+	key, err := c.cookieKey("sign")
+	if err != nil {
+		return "", err
+	}
+	cookie, err := c.Req.Cookie(name)
+	if err != nil {
+		return "", err
+	}
+	payload, sig, ok := strings.Cut(cookie.Value, ".")
+	if !ok || !hmac.Equal([]byte(sig), []byte(signCookie(key, name, payload))) {
+		return "", ErrInvalidCookie
+	}
+	value, err := base64.RawURLEncoding.DecodeString(payload)
+	if err != nil {
+		return "", ErrInvalidCookie
+	}
+	return string(value), nil
+}
+
+// SetEncryptedCookie 设置使用 AES-256-GCM 加密的 Cookie, 客户端既无法读取也无法篡改
+func (c *Context) SetEncryptedCookie(name, value string, opts CookieOptions) error {
+	aead, err := c.cookieCipher()
+	if err != nil {
+		return err
+	}
+	nonce := make([]byte, aead.NonceSize(), aead.NonceSize()+len(value)+aead.Overhead())
+	if _, err := rand.Read(nonce); err != nil {
+		return err
+	}
+	// 以 Cookie 名称作为附加数据, 密文不能挪用到其他 Cookie
+	sealed := aead.Seal(nonce, nonce, []byte(value), []byte(name))
+	c.writeCookie(name, base64.RawURLEncoding.EncodeToString(sealed), opts)
+	return nil
+}
+
+// EncryptedCookie 获取并解密 Cookie, 解密失败时返回 ErrInvalidCookie
+func (c *Context) EncryptedCookie(name string) (string, error) {
+	aead, err := c.cookieCipher()
+	if err != nil {
+		return "", err
+	}
+	cookie, err := c.Req.Cookie(name)
+	if err != nil {
+		return "", err
+	}
+	sealed, err := base64.RawURLEncoding.DecodeString(cookie.Value)
+	if err != nil || len(sealed) < aead.NonceSize() {
+		return "", ErrInvalidCookie
+	}
+	nonce, ciphertext := sealed[:aead.NonceSize()], sealed[aead.NonceSize():]
+	value, err := aead.Open(nil, nonce, ciphertext, []byte(name))
+	if err != nil {
+		return "", ErrInvalidCookie
+	}
+	return string(value), nil
+}
+
+// cookieKey 由 Engine.CookieSecret 派生指定用途的32字节密钥, 签名和加密使用不同的密钥
+func (c *Context) cookieKey(purpose string) ([]byte, error) {
+	if c.engine == nil || len(c.engine.CookieSecret) == 0 {
+		return nil, ErrCookieSecretNotSet
+	}
+	mac := hmac.New(sha256.New, c.engine.CookieSecret)
+	mac.Write([]byte("gooo-cookie-" + purpose))
+	return mac.Sum(nil), nil
+}
+
+// cookieCipher 返回加密 Cookie 使用的 AES-GCM
+func (c *Context) cookieCipher() (cipher.AEAD, error) {
+	key, err := c.cookieKey("encrypt")
+	if err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// signCookie 计算 Cookie 名称和内容的签名
+func signCookie(key []byte, name, payload string) string {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(name + "=" + payload))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
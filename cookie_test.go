@@ -0,0 +1,151 @@
+package gooo
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// cookieRoundTrip 在 handler 中设置 Cookie, 再带着这些 Cookie 发起第二个请求
+func cookieRoundTrip(t *testing.T, engine *Engine, tamper func(*http.Cookie)) *httptest.ResponseRecorder {
+	t.Helper()
+	w := httptest.NewRecorder()
+	engine.ServeHTTP(w, httptest.NewRequest("GET", "/set", nil))
+	req := httptest.NewRequest("GET", "/get", nil)
+	for _, cookie := range w.Result().Cookies() {
+		if tamper != nil {
+			tamper(cookie)
+		}
+		req.AddCookie(cookie)
+	}
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, req)
+	return w
+}
+
+func TestContext_SetCookie(t *testing.T) {
+	w := httptest.NewRecorder()
+	c := newContext(w, httptest.NewRequest("GET", "/", nil))
+	c.SetCookie("user", "a b;c", CookieOptions{
+		Domain:      "example.com",
+		MaxAge:      60,
+		HttpOnly:    true,
+		SameSite:    http.SameSiteNoneMode,
+		Partitioned: true,
+	})
+	c.Writer.(ResponseWriter).WriteHeaderNow()
+
+	header := w.Header().Get("Set-Cookie")
+	for _, part := range []string{"user=a+b%3Bc", "Path=/", "Domain=example.com", "Max-Age=60",
+		"HttpOnly", "Secure", "SameSite=None", "Partitioned"} {
+		if !strings.Contains(header, part) {
+			t.Errorf("Set-Cookie %q should contain %q", header, part)
+		}
+	}
+
+	req := httptest.NewRequest("GET", "/", nil)
+	req.Header.Set("Cookie", "user=a+b%3Bc")
+	c = newContext(httptest.NewRecorder(), req)
+	if v, err := c.Cookie("user"); err != nil || v != "a b;c" {
+		t.Errorf("Expected decoded cookie, got %q %v", v, err)
+	}
+	if _, err := c.Cookie("missing"); !errors.Is(err, http.ErrNoCookie) {
+		t.Errorf("Expected ErrNoCookie, got %v", err)
+	}
+}
+
+func TestContext_SignedCookie(t *testing.T) {
+	engine := New()
+	engine.CookieSecret = []byte("0123456789abcdef0123456789abcdef")
+	engine.GET("/set", func(c *Context) {
+		if err := c.SetSignedCookie("cart", "item=1,2", CookieOptions{}); err != nil {
+			t.Fatal(err)
+		}
+	})
+	engine.GET("/get", func(c *Context) {
+		v, err := c.SignedCookie("cart")
+		if err != nil {
+			c.String(http.StatusBadRequest, err.Error())
+			return
+		}
+		c.String(http.StatusOK, v)
+	})
+
+	if w := cookieRoundTrip(t, engine, nil); w.Body.String() != "item=1,2" {
+		t.Errorf("Unexpected value %q", w.Body.String())
+	}
+	w := cookieRoundTrip(t, engine, func(cookie *http.Cookie) {
+		cookie.Value = "aXRlbT05." + strings.SplitN(cookie.Value, ".", 2)[1]
+	})
+	if w.Code != http.StatusBadRequest || w.Body.String() != ErrInvalidCookie.Error() {
+		t.Errorf("Tampered cookie should be rejected, got %d %q", w.Code, w.Body.String())
+	}
+	// 签名与 Cookie 名称绑定
+	w = cookieRoundTrip(t, engine, func(cookie *http.Cookie) { cookie.Name = "other" })
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Renamed cookie should not be found, got %d", w.Code)
+	}
+}
+
+func TestContext_EncryptedCookie(t *testing.T) {
+	engine := New()
+	engine.CookieSecret = []byte("secret")
+	engine.GET("/set", func(c *Context) {
+		if err := c.SetEncryptedCookie("state", "uid=42", CookieOptions{HttpOnly: true}); err != nil {
+			t.Fatal(err)
+		}
+	})
+	engine.GET("/get", func(c *Context) {
+		v, err := c.EncryptedCookie("state")
+		if err != nil {
+			c.String(http.StatusBadRequest, err.Error())
+			return
+		}
+		c.String(http.StatusOK, v)
+	})
+
+	var plain string
+	w := cookieRoundTrip(t, engine, func(cookie *http.Cookie) { plain = cookie.Value })
+	if w.Body.String() != "uid=42" {
+		t.Errorf("Unexpected value %q", w.Body.String())
+	}
+	if strings.Contains(plain, "uid") {
+		t.Errorf("Cookie should be encrypted, got %q", plain)
+	}
+
+	w = cookieRoundTrip(t, engine, func(cookie *http.Cookie) {
+		b := []byte(cookie.Value)
+		b[len(b)-2] ^= 1
+		cookie.Value = string(b)
+	})
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Tampered cookie should be rejected, got %d", w.Code)
+	}
+
+	// 更换密钥后旧 Cookie 失效
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, httptest.NewRequest("GET", "/set", nil))
+	engine.CookieSecret = []byte("rotated")
+	req := httptest.NewRequest("GET", "/get", nil)
+	for _, cookie := range w.Result().Cookies() {
+		req.AddCookie(cookie)
+	}
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, req)
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Cookie from old secret should be rejected, got %d", w.Code)
+	}
+}
+
+func TestContext_CookieSecretNotSet(t *testing.T) {
+	c := New().allocateContext()
+	c.reset(httptest.NewRecorder(), httptest.NewRequest("GET", "/", nil))
+	if err := c.SetSignedCookie("a", "b", CookieOptions{}); !errors.Is(err, ErrCookieSecretNotSet) {
+		t.Errorf("Expected ErrCookieSecretNotSet, got %v", err)
+	}
+	if _, err := c.EncryptedCookie("a"); !errors.Is(err, ErrCookieSecretNotSet) {
+		t.Errorf("Expected ErrCookieSecretNotSet, got %v", err)
+	}
+}
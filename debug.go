@@ -25,3 +25,17 @@ func DebugPrint(format string, v ...any) {
 func SetDebugMode(b bool) {
 	DebugMode = b
 }
+
+// debugPrintRoutes 调试模式下打印路由表
+func debugPrintRoutes(routes []RouteInfo) {
+	if !IsDebugMode() {
+		return
+	}
+	for _, r := range routes {
+		name := ""
+		if r.Name != "" {
+			name = " (" + r.Name + ")"
+		}
+		DebugPrint("%-7s %-30s --> %s (%d middlewares)%s", r.Method, r.Host+r.Path, r.Handler, r.Middlewares, name)
+	}
+}
@@ -1,9 +1,10 @@
 package gooo
 
 import (
+	"net"
 	"net/http"
 	"path/filepath"
-	"strings"
+	"sync"
 	"time"
 )
 
@@ -13,10 +14,40 @@ type HandlerFunc func(c *Context)
 type Engine struct {
 	*RouterGroup
 	router         *router
-	groups         []*RouterGroup
 	config         *Config
 	template       *TemplateEngine // 替换原有字段
 	sessionManager *SessionManager
+	noRoute        []HandlerFunc // 404 处理器链
+	noMethod       []HandlerFunc // 405 处理器链
+	allNoRoute     []HandlerFunc // 全局中间件 + 404 处理器链
+	allNoMethod    []HandlerFunc // 全局中间件 + 405 处理器链
+	staticNode     *node         // 内置静态文件路由, 全局中间件变化时重建其处理器链
+	staticHandler  HandlerFunc
+	namedRoutes    map[string]*Route
+	hosts          []*hostRoute
+	pool           sync.Pool    // Context 对象池
+	trustedCIDRs   []*net.IPNet // 受信任的代理, 由 SetTrustedProxies 设置
+
+	// HandleMethodNotAllowed 为真时, 若路径在其他方法下已注册, 返回405并设置Allow头
+	HandleMethodNotAllowed bool
+	// RedirectTrailingSlash 为真时, 若仅结尾斜杠不同的路径已注册, 重定向到该路径
+	RedirectTrailingSlash bool
+	// RedirectFixedPath 为真时, 清理多余斜杠和 . / .. 片段后若能匹配路由, 重定向到清理后的路径
+	RedirectFixedPath bool
+
+	// MaxMultipartMemory 解析 multipart 表单时保存在内存中的最大字节数, 超出部分写入临时文件
+	MaxMultipartMemory int64
+	// MaxUploadFileSize FormFile 允许的单个文件最大字节数, 0 表示不限制
+	MaxUploadFileSize int64
+	// MaxRequestBodySize 解析表单和读取请求体时允许的最大字节数, 超出时停止读取, 0 表示不限制.
+	// 未设置而设置了 MaxUploadFileSize 时, multipart 请求体限制为 MaxUploadFileSize + MaxMultipartMemory
+	MaxRequestBodySize int64
+	// AllowedUploadTypes FormFile 允许的文件类型(按内容嗅探), 如 image/png, image/*; 为空表示不限制
+	AllowedUploadTypes []string
+	// CookieSecret 签名和加密 Cookie 使用的密钥, 建议至少32字节随机数据
+	CookieSecret []byte
+	// SecureJSONPrefix SecureJSON 输出的前缀, 默认 while(1);
+	SecureJSONPrefix string
 }
 
 func (e *Engine) GetSessionManager() *SessionManager {
@@ -24,6 +55,12 @@ func (e *Engine) GetSessionManager() *SessionManager {
 }
 
 func New() *Engine {
+	return newEngine()
+}
+
+// newEngine 创建Engine. 内置静态文件路由的处理器链在全局中间件变化时重建,
+// 因此之后通过 Use 添加的全局中间件同样作用于静态文件
+func newEngine(middlewares ...HandlerFunc) *Engine {
 	engine := &Engine{
 		router: newRouter(),
 		config: &Config{
@@ -34,11 +71,21 @@ func New() *Engine {
 		},
 		template:       NewTemplateEngine(),
 		sessionManager: NewSessionManager(NewMemoryStore(30 * time.Minute)),
+
+		HandleMethodNotAllowed: true,
+		RedirectTrailingSlash:  true,
+		MaxMultipartMemory:     defaultMultipartMemory,
+		SecureJSONPrefix:       defaultSecureJSONPrefix,
 	}
 	engine.RouterGroup = &RouterGroup{engine: engine}
+	engine.pool.New = func() any {
+		return engine.allocateContext()
+	}
+	engine.Use(middlewares...)
 	// 加载静态文件
-	engine.Static("/static", engine.config.StaticPath)
+	engine.staticNode, engine.staticHandler = engine.static("/static", engine.config.StaticPath)
 	// 加载模板
+	engine.template.AddFunc("url", engine.URL)
 	pattern := filepath.Join(engine.config.TemplatePath, "*"+engine.config.Extension)
 	if err := engine.template.Load(pattern); err != nil && IsDebugMode() {
 		DebugPrint("模板加载警告: %v", err) // 调试模式下打印警告
@@ -53,40 +100,65 @@ func New() *Engine {
 }
 
 func (engine *Engine) ServeHTTP(w http.ResponseWriter, r *http.Request) {
-	c := newContext(w, r)
-	c.engine = engine
-
-	// 1. 收集所有中间件(全局+匹配路由组)
-	var handlers []HandlerFunc
-
-	// 添加全局中间件
-	handlers = append(handlers, engine.RouterGroup.middlewares...)
+	c := engine.pool.Get().(*Context)
+	c.reset(w, r)
+	engine.matchHost(r.Host, &c.Params).handler(c)
+	// 只设置了状态码而没有写响应体时, 在这里发送响应头
+	c.writer.WriteHeaderNow()
+	engine.pool.Put(c)
+}
 
-	// 添加匹配路由组的中间件
-	for _, group := range engine.groups {
-		if group != engine.RouterGroup && strings.HasPrefix(r.URL.Path, group.prefix) {
-			handlers = append(handlers, group.middlewares...)
+// allocateContext 为对象池创建 Context, 按最大参数数量预分配 Params
+func (engine *Engine) allocateContext() *Context {
+	maxParams := engine.router.maxParams
+	for _, h := range engine.hosts {
+		if n := h.router.maxParams + len(h.labels); n > maxParams {
+			maxParams = n
 		}
 	}
+	return &Context{engine: engine, Params: make(Params, 0, maxParams)}
+}
 
-	// 2. 添加路由处理器
-	handlers = append(handlers, func(c *Context) {
-		engine.router.handler(c)
-	})
+// NoRoute 设置404响应的处理器链, 与普通路由一样在全局中间件之后执行
+func (engine *Engine) NoRoute(handlers ...HandlerFunc) {
+	engine.noRoute = handlers
+	engine.rebuildErrorHandlers()
+}
+
+// NoMethod 设置405响应的处理器链, 与普通路由一样在全局中间件之后执行
+func (engine *Engine) NoMethod(handlers ...HandlerFunc) {
+	engine.noMethod = handlers
+	engine.rebuildErrorHandlers()
+}
 
-	// 3. 执行处理链
-	c.handlers = handlers
-	c.index = -1
-	c.Next()
+// rebuildErrorHandlers 将全局中间件与404/405处理器合并
+func (engine *Engine) rebuildErrorHandlers() {
+	noRoute := engine.noRoute
+	if len(noRoute) == 0 {
+		noRoute = []HandlerFunc{notFoundHandler}
+	}
+	noMethod := engine.noMethod
+	if len(noMethod) == 0 {
+		noMethod = []HandlerFunc{methodNotAllowedHandler}
+	}
+	engine.allNoRoute = engine.RouterGroup.combineHandlers(noRoute)
+	engine.allNoMethod = engine.RouterGroup.combineHandlers(noMethod)
+}
+
+// rebuildStaticHandlers 将全局中间件与内置静态文件路由的处理器合并
+func (engine *Engine) rebuildStaticHandlers() {
+	if engine.staticNode != nil {
+		engine.staticNode.handlers = engine.RouterGroup.combineHandlers([]HandlerFunc{engine.staticHandler})
+	}
 }
 
 func Default() *Engine {
-	engine := New()
-	engine.Use(Logger(), Recovery())
-	return engine
+	return newEngine(Logger(), Recovery())
 }
 
 func (engine *Engine) Run(addr ...string) (err error) {
 	port := resolveAddress(addr)
+	debugPrintRoutes(engine.Routes())
+	DebugPrint("Listening and serving HTTP on %s", port)
 	return http.ListenAndServe(port, engine)
 }
@@ -0,0 +1,106 @@
+package gooo
+
+import (
+	"net"
+	"strings"
+)
+
+// hostRoute 按 Host 划分的路由表
+type hostRoute struct {
+	pattern string
+	labels  []string // 按 "." 拆分的 Host 模式, {name} 表示参数
+	router  *router
+}
+
+// Host 返回绑定到指定 Host 的路由组, 该组的路由注册在独立的前缀树中.
+// 模式中的 {name} 匹配一个域名标签, 可通过 c.Param("name") 获取:
+//
+//	engine.Host("api.example.com").GET("/status", h)
+//	engine.Host("{tenant}.example.com").GET("/", h) // c.Param("tenant")
+//
+// 请求的 Host 命中某个模式后只在该模式的路由表中查找, 未命中任何模式时使用默认路由表.
+// 精确 Host 优先于带参数的模式, 同类模式按注册顺序匹配.
+func (engine *Engine) Host(pattern string) *RouterGroup {
+	pattern = strings.ToLower(pattern)
+	var host *hostRoute
+	for _, h := range engine.hosts {
+		if h.pattern == pattern {
+			host = h
+			break
+		}
+	}
+	if host == nil {
+		host = &hostRoute{
+			pattern: pattern,
+			labels:  strings.Split(pattern, "."),
+			router:  newRouter(),
+		}
+		engine.hosts = append(engine.hosts, host)
+	}
+	return &RouterGroup{
+		parent: engine.RouterGroup,
+		engine: engine,
+		router: host.router,
+		host:   pattern,
+	}
+}
+
+// isStatic 模式中是否不含参数
+func (h *hostRoute) isStatic() bool {
+	return !strings.Contains(h.pattern, "{")
+}
+
+// match 匹配请求 Host (不区分大小写), 成功时将小写的 Host 参数追加到 params
+func (h *hostRoute) match(host string, params *Params) bool {
+	if strings.Count(host, ".") != len(h.labels)-1 {
+		return false
+	}
+	mark := len(*params)
+	for _, label := range h.labels {
+		value := host
+		if i := strings.IndexByte(host, '.'); i >= 0 {
+			value, host = host[:i], host[i+1:]
+		}
+		if strings.HasPrefix(label, "{") && strings.HasSuffix(label, "}") {
+			if value == "" {
+				*params = (*params)[:mark]
+				return false
+			}
+			// 域名不区分大小写, 参数值统一为小写(已是小写时不分配内存)
+			*params = append(*params, Param{Key: label[1 : len(label)-1], Value: strings.ToLower(value)})
+			continue
+		}
+		if !strings.EqualFold(label, value) {
+			*params = (*params)[:mark]
+			return false
+		}
+	}
+	return true
+}
+
+// matchHost 查找请求 Host 对应的路由表并追加 Host 参数, 未命中时返回默认路由表
+func (engine *Engine) matchHost(host string, params *Params) *router {
+	if len(engine.hosts) == 0 {
+		return engine.router
+	}
+	hostname := host
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		hostname = h
+	}
+	for _, static := range []bool{true, false} {
+		for _, h := range engine.hosts {
+			if h.isStatic() != static {
+				continue
+			}
+			// 模式中带端口时与完整 Host 比较
+			target := hostname
+			if strings.Contains(h.pattern, ":") {
+				target = host
+			}
+			if h.match(target, params) {
+				return h.router
+			}
+		}
+	}
+	return engine.router
+}
@@ -0,0 +1,68 @@
+package gooo
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestEngine_Host(t *testing.T) {
+	engine := New()
+	engine.Use(func(c *Context) {
+		c.SetHeader("X-Global", "1")
+		c.Next()
+	})
+	engine.GET("/", func(c *Context) { c.String(http.StatusOK, "default") })
+	engine.Host("api.example.com").GET("/", func(c *Context) {
+		c.String(http.StatusOK, "api")
+	})
+	tenant := engine.Host("{tenant}.example.com").Group("/v1")
+	tenant.GET("/users/:id", func(c *Context) {
+		c.String(http.StatusOK, "%s:%s", c.Param("tenant"), c.Param("id"))
+	})
+
+	tests := []struct {
+		host string
+		path string
+		code int
+		body string
+	}{
+		{"api.example.com", "/", http.StatusOK, "api"},
+		{"API.example.com:8080", "/", http.StatusOK, "api"},
+		{"acme.example.com", "/v1/users/7", http.StatusOK, "acme:7"},
+		{"ACME.Example.com", "/v1/users/7", http.StatusOK, "acme:7"},
+		{"acme.example.com", "/", http.StatusNotFound, ""},
+		{"example.org", "/", http.StatusOK, "default"},
+		{"a.b.example.com", "/v1/users/7", http.StatusNotFound, ""},
+	}
+	for _, tt := range tests {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest("GET", tt.path, nil)
+		req.Host = tt.host
+		engine.ServeHTTP(w, req)
+
+		if w.Code != tt.code {
+			t.Errorf("%s%s: expected status %d, got %d", tt.host, tt.path, tt.code, w.Code)
+		}
+		if tt.code == http.StatusOK && w.Body.String() != tt.body {
+			t.Errorf("%s%s: expected body '%s', got '%s'", tt.host, tt.path, tt.body, w.Body.String())
+		}
+		if w.Header().Get("X-Global") != "1" {
+			t.Errorf("%s%s: global middleware not executed", tt.host, tt.path)
+		}
+	}
+}
+
+func TestEngine_HostRoutes(t *testing.T) {
+	engine := New()
+	engine.GET("/", func(c *Context) {})
+	engine.Host("api.example.com").GET("/", func(c *Context) {}).Name("api.home")
+
+	routes := engine.Routes()
+	if len(routes) != 2 {
+		t.Fatalf("expected 2 routes, got %+v", routes)
+	}
+	if routes[1].Host != "api.example.com" || routes[1].Name != "api.home" {
+		t.Errorf("unexpected host route: %+v", routes[1])
+	}
+}
@@ -12,6 +12,6 @@ func Logger() HandlerFunc {
 		// Process request
 		c.Next()
 		// Calculate resolution time
-		log.Printf("[%d] %s in %v", c.Response.StatusCode, c.Req.RequestURI, time.Since(t))
+		log.Printf("[%d] %s %s in %v", c.GetStatusCode(), c.ClientIP(), c.Req.RequestURI, time.Since(t))
 	}
 }
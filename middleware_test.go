@@ -3,6 +3,8 @@ package gooo
 import (
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"testing"
 )
 
@@ -120,3 +122,145 @@ func TestStaticDirectoryCheck(t *testing.T) {
 		engine.Static("/static", "non_existing_dir")
 	})
 }
+
+func TestStaticRouteGlobalMiddleware(t *testing.T) {
+	// 内置静态文件路由读取工作目录下的 web/static
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "web", "static"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "web", "static", "x"), []byte("x"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+	defer os.Chdir(wd)
+
+	// New 之后再添加的全局中间件同样作用于静态文件路由
+	engine := New()
+	engine.Use(func(c *Context) {
+		c.SetHeader("X-Global", "1")
+		c.Next()
+	})
+
+	w := httptest.NewRecorder()
+	engine.ServeHTTP(w, httptest.NewRequest("GET", "/static/x", nil))
+	if w.Code != http.StatusOK || w.Body.String() != "x" {
+		t.Fatalf("Expected static file, got %d %q", w.Code, w.Body.String())
+	}
+	if w.Header().Get("X-Global") != "1" {
+		t.Error("Global middleware should run for the static route")
+	}
+}
+
+func TestRouteMiddleware(t *testing.T) {
+	engine := New()
+	executionLog := make([]string, 0)
+
+	auth := func(c *Context) {
+		if c.Req.Header.Get("Authorization") == "" {
+			c.String(http.StatusUnauthorized, "unauthorized")
+			c.Abort()
+			return
+		}
+		executionLog = append(executionLog, "auth")
+		c.Next()
+		executionLog = append(executionLog, "auth-end")
+	}
+	validate := func(c *Context) {
+		executionLog = append(executionLog, "validate")
+	}
+
+	engine.GET("/secure", auth, validate, func(c *Context) {
+		executionLog = append(executionLog, "handler")
+		c.String(http.StatusOK, "OK")
+	})
+	engine.POST("/secure", auth, func(c *Context) {
+		c.String(http.StatusCreated, "created")
+	})
+
+	t.Run("chain runs in order", func(t *testing.T) {
+		executionLog = executionLog[:0]
+		req := httptest.NewRequest("GET", "/secure", nil)
+		req.Header.Set("Authorization", "token")
+		w := httptest.NewRecorder()
+		engine.ServeHTTP(w, req)
+
+		expected := []string{"auth", "validate", "handler", "auth-end"}
+		if len(executionLog) != len(expected) {
+			t.Fatalf("expected %v, got %v", expected, executionLog)
+		}
+		for i, step := range expected {
+			if executionLog[i] != step {
+				t.Errorf("step %d: expected %s, got %s", i, step, executionLog[i])
+			}
+		}
+	})
+
+	t.Run("abort stops handler", func(t *testing.T) {
+		executionLog = executionLog[:0]
+		req := httptest.NewRequest("POST", "/secure", nil)
+		w := httptest.NewRecorder()
+		engine.ServeHTTP(w, req)
+
+		if w.Code != http.StatusUnauthorized {
+			t.Errorf("expected status 401, got %d", w.Code)
+		}
+		if len(executionLog) != 0 {
+			t.Errorf("handler should not run after abort, got %v", executionLog)
+		}
+	})
+}
+
+func TestGroupMiddlewareAttachment(t *testing.T) {
+	engine := New()
+	executionLog := make([]string, 0)
+	record := func(name string) HandlerFunc {
+		return func(c *Context) {
+			executionLog = append(executionLog, name)
+		}
+	}
+
+	engine.Use(record("global"))
+	api := engine.Group("/api")
+	api.Use(record("api"))
+	api.Use(record("api2"))
+	v1 := api.Group("/v1")
+	v1.Use(record("v1"))
+
+	v1.GET("/users", record("users"))
+	api.GET("/ping", record("ping"))
+	engine.GET("/apix", record("apix"))
+
+	tests := []struct {
+		path     string
+		expected []string
+	}{
+		{"/api/v1/users", []string{"global", "api", "api2", "v1", "users"}},
+		{"/api/ping", []string{"global", "api", "api2", "ping"}},
+		{"/apix", []string{"global", "apix"}},
+		{"/api/missing", []string{"global"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			executionLog = executionLog[:0]
+			req := httptest.NewRequest("GET", tt.path, nil)
+			engine.ServeHTTP(httptest.NewRecorder(), req)
+
+			if len(executionLog) != len(tt.expected) {
+				t.Fatalf("expected %v, got %v", tt.expected, executionLog)
+			}
+			for i, step := range tt.expected {
+				if executionLog[i] != step {
+					t.Errorf("step %d: expected %s, got %s", i, step, executionLog[i])
+				}
+			}
+		})
+	}
+}
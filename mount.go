@@ -0,0 +1,59 @@
+package gooo
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// mountParam Mount 注册的通配符参数名
+const mountParam = "mountpath"
+
+// WrapH 将 http.Handler 包装为 HandlerFunc
+func WrapH(h http.Handler) HandlerFunc {
+	return func(c *Context) {
+		h.ServeHTTP(c.Writer, c.Req)
+	}
+}
+
+// WrapF 将 http.HandlerFunc 包装为 HandlerFunc
+func WrapF(f http.HandlerFunc) HandlerFunc {
+	return func(c *Context) {
+		f(c.Writer, c.Req)
+	}
+}
+
+// Mount 将 http.Handler (包括其他 Engine) 挂载到 prefix 下, 处理所有HTTP方法.
+// 被挂载的处理器看到的是去除前缀后的路径, 请求仍会先经过当前路由组的中间件.
+//
+//	engine.Group("/admin").Mount("/blog", blogEngine) // /admin/blog/posts -> blogEngine 收到 /posts
+func (group *RouterGroup) Mount(prefix string, h http.Handler) *Route {
+	prefix = strings.TrimSuffix(prefix, "/")
+	fullPrefix := strings.TrimSuffix(group.prefix+prefix, "/")
+	if fullPrefix != "" && fullPrefix[0] != '/' {
+		fullPrefix = "/" + fullPrefix
+	}
+	return group.Any(prefix+"/*"+mountParam, func(c *Context) {
+		h.ServeHTTP(c.Writer, stripPrefix(c.Req, fullPrefix))
+	})
+}
+
+// stripPrefix 返回去除路径前缀后的请求副本
+func stripPrefix(req *http.Request, prefix string) *http.Request {
+	p := strings.TrimPrefix(req.URL.Path, prefix)
+	if p == "" || p[0] != '/' {
+		p = "/" + p
+	}
+	rp := strings.TrimPrefix(req.URL.RawPath, prefix)
+	if req.URL.RawPath != "" && (rp == "" || rp[0] != '/') {
+		rp = "/" + rp
+	}
+
+	r2 := new(http.Request)
+	*r2 = *req
+	r2.URL = new(url.URL)
+	*r2.URL = *req.URL
+	r2.URL.Path = p
+	r2.URL.RawPath = rp
+	return r2
+}
@@ -0,0 +1,74 @@
+package gooo
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRouterGroup_Mount(t *testing.T) {
+	sub := New()
+	sub.GET("/posts/:id", func(c *Context) {
+		c.String(http.StatusOK, "post %s at %s", c.Param("id"), c.Req.URL.Path)
+	})
+	sub.POST("/", func(c *Context) {
+		c.String(http.StatusCreated, "root")
+	})
+
+	engine := New()
+	admin := engine.Group("/admin")
+	admin.Use(func(c *Context) {
+		c.SetHeader("X-Admin", "1")
+		c.Next()
+	})
+	admin.Mount("/blog", sub)
+	engine.Mount("/raw", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(r.Method + " " + r.URL.Path))
+	}))
+
+	tests := []struct {
+		method string
+		path   string
+		code   int
+		body   string
+		admin  bool
+	}{
+		{"GET", "/admin/blog/posts/3", http.StatusOK, "post 3 at /posts/3", true},
+		{"POST", "/admin/blog", http.StatusCreated, "root", true},
+		{"POST", "/admin/blog/", http.StatusCreated, "root", true},
+		{"DELETE", "/raw/a/b/", http.StatusOK, "DELETE /a/b/", false},
+	}
+	for _, tt := range tests {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(tt.method, tt.path, nil)
+		engine.ServeHTTP(w, req)
+
+		if w.Code != tt.code {
+			t.Errorf("%s %s: expected status %d, got %d", tt.method, tt.path, tt.code, w.Code)
+		}
+		if body := w.Body.String(); body != tt.body {
+			t.Errorf("%s %s: expected body '%s', got '%s'", tt.method, tt.path, tt.body, body)
+		}
+		if (w.Header().Get("X-Admin") == "1") != tt.admin {
+			t.Errorf("%s %s: group middleware mismatch", tt.method, tt.path)
+		}
+	}
+}
+
+func TestWrapHandlers(t *testing.T) {
+	engine := New()
+	engine.GET("/h", WrapH(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("wrapped handler"))
+	})))
+	engine.GET("/f", WrapF(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("wrapped func"))
+	}))
+
+	for path, expected := range map[string]string{"/h": "wrapped handler", "/f": "wrapped func"} {
+		w := httptest.NewRecorder()
+		engine.ServeHTTP(w, httptest.NewRequest("GET", path, nil))
+		if body := w.Body.String(); body != expected {
+			t.Errorf("%s: expected '%s', got '%s'", path, expected, body)
+		}
+	}
+}
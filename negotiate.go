@@ -0,0 +1,185 @@
+package gooo
+
+import (
+	"errors"
+	"fmt"
+	"html"
+	"html/template"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// 内容协商错误
+var (
+	ErrNotAcceptable     = errors.New("not acceptable")
+	ErrUnsupportedFormat = errors.New("unsupported negotiate format")
+)
+
+// Negotiate 内容协商的候选格式及各格式的数据
+type Negotiate struct {
+	Offered  []string // 支持的格式, 按服务端偏好排序, 可选 MIMEJSON / MIMEXML / MIMEHTML / MIMEPlain / MIMEYAML / MIMEMsgPack / MIMEProtoBuf / MIMETOML
+	HTMLName string   // HTML 格式使用的模板名, 为空时将 HTMLData 作为 HTML 字符串输出
+	HTMLData any      // 非字符串数据会被转义; HTMLName 和 HTMLData 都未设置时不提供 HTML 格式
+	JSONData any
+	XMLData  any
+	TextData any
+	Data     any // 各格式未单独指定数据时使用, HTML 格式不使用
+}
+
+// acceptRange Accept 头中的一项
+type acceptRange struct {
+	typ, subtype string
+	q            float64
+}
+
+// parseAccept 解析 Accept 头, 忽略格式错误的项
+func parseAccept(header string) []acceptRange {
+	ranges := make([]acceptRange, 0)
+	for _, item := range strings.Split(header, ",") {
+		mediaRange, params, _ := strings.Cut(strings.TrimSpace(item), ";")
+		typ, subtype, ok := strings.Cut(strings.ToLower(strings.TrimSpace(mediaRange)), "/")
+		if !ok || typ == "" || subtype == "" || (typ == "*" && subtype != "*") {
+			continue
+		}
+		r := acceptRange{typ: typ, subtype: subtype, q: 1}
+		for _, param := range strings.Split(params, ";") {
+			key, value, _ := strings.Cut(strings.TrimSpace(param), "=")
+			if strings.EqualFold(key, "q") {
+				if q, err := strconv.ParseFloat(value, 64); err == nil && q >= 0 && q <= 1 {
+					r.q = q
+				}
+			}
+		}
+		ranges = append(ranges, r)
+	}
+	return ranges
+}
+
+// specificity 返回该项与 MIME 类型的匹配程度: -1 不匹配, 0 */*, 1 type/*, 2 完全匹配
+func (r acceptRange) specificity(mimeType string) int {
+	typ, subtype, _ := strings.Cut(mimeType, "/")
+	switch {
+	case r.typ == "*":
+		return 0
+	case r.typ != typ:
+		return -1
+	case r.subtype == "*":
+		return 1
+	case r.subtype == subtype:
+		return 2
+	default:
+		return -1
+	}
+}
+
+// NegotiateFormat 根据 Accept 头从 offered 中选出最合适的格式, 没有可接受的格式时返回空串.
+// 每个候选格式取最具体的匹配项的 q 值; q 值相同时, 被明确列出的格式优先于通配符匹配, 再按 offered 的顺序.
+// 没有 Accept 头时返回第一个候选.
+func (c *Context) NegotiateFormat(offered ...string) string {
+	if len(offered) == 0 {
+		return ""
+	}
+	header := ""
+	if c.Req != nil {
+		header = strings.Join(c.Req.Header.Values("Accept"), ",")
+	}
+	if strings.TrimSpace(header) == "" {
+		return offered[0]
+	}
+	ranges := parseAccept(header)
+
+	best, bestQ, bestLevel := "", 0.0, -1
+	for _, offer := range offered {
+		q, level := 0.0, -1
+		for _, r := range ranges {
+			if s := r.specificity(strings.ToLower(offer)); s > level {
+				q, level = r.q, s
+			}
+		}
+		if q > bestQ || (q > 0 && q == bestQ && level > bestLevel) {
+			best, bestQ, bestLevel = offer, q, level
+		}
+	}
+	return best
+}
+
+// Negotiate 根据 Accept 头选择格式输出, 没有可接受的格式时返回406并终止处理器链;
+// 选中的格式不受支持时返回500并终止处理器链. YAML / MsgPack / ProtoBuf / TOML 使用 Data
+func (c *Context) Negotiate(code int, config Negotiate) {
+	offered := config.Offered
+	// Data 通常是为 JSON 等格式准备的, 不能未经转义地作为 HTML 输出, 没有 HTML 数据时不提供 HTML 格式
+	if config.HTMLName == "" && config.HTMLData == nil {
+		offered = make([]string, 0, len(config.Offered))
+		for _, format := range config.Offered {
+			if format != MIMEHTML {
+				offered = append(offered, format)
+			}
+		}
+	}
+	switch format := c.NegotiateFormat(offered...); format {
+	case MIMEJSON:
+		c.JSON(code, negotiateData(config.JSONData, config.Data))
+	case MIMEXML, MIMEXML2:
+		c.XML(code, negotiateData(config.XMLData, config.Data))
+	case MIMEHTML:
+		if config.HTMLName == "" {
+			c.HTML(code, htmlString(config.HTMLData))
+			return
+		}
+		c.SetContentType("text/html")
+		c.Status(code)
+		c.View(config.HTMLName, config.HTMLData)
+	case MIMEPlain:
+		c.String(code, "%s", toString(negotiateData(config.TextData, config.Data)))
+	case MIMEYAML, MIMEYAML2:
+		c.YAML(code, config.Data)
+	case MIMEMsgPack, MIMEMsgPack2:
+		c.MsgPack(code, config.Data)
+	case MIMEProtoBuf:
+		c.ProtoBuf(code, config.Data)
+	case MIMETOML:
+		c.TOML(code, config.Data)
+	case "":
+		c.Response.Error(http.StatusNotAcceptable, ErrNotAcceptable)
+		c.Abort()
+	default:
+		DebugPrint("Negotiate 不支持的格式: %s", format)
+		c.Response.Error(http.StatusInternalServerError, fmt.Errorf("%w: %s", ErrUnsupportedFormat, format))
+		c.Abort()
+	}
+}
+
+// negotiateData 优先使用格式专用的数据
+func negotiateData(specific, fallback any) any {
+	if specific != nil {
+		return specific
+	}
+	return fallback
+}
+
+// htmlString 字符串按 HTML 原样输出, 其他数据转换为字符串后转义
+func htmlString(data any) string {
+	switch v := data.(type) {
+	case string:
+		return v
+	case template.HTML:
+		return string(v)
+	default:
+		return html.EscapeString(toString(v))
+	}
+}
+
+// toString 将数据转换为字符串输出
+func toString(data any) string {
+	switch v := data.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case []byte:
+		return string(v)
+	default:
+		return fmt.Sprint(v)
+	}
+}
@@ -0,0 +1,159 @@
+package gooo
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestNegotiateFormat(t *testing.T) {
+	offered := []string{MIMEJSON, MIMEXML, MIMEHTML, MIMEPlain}
+	tests := []struct {
+		accept   string
+		offered  []string
+		expected string
+	}{
+		{"", offered, MIMEJSON},
+		{"application/xml", offered, MIMEXML},
+		{"text/html,application/xhtml+xml,application/xml;q=0.9,*/*;q=0.8", offered, MIMEHTML},
+		{"application/json;q=0.5, application/xml;q=0.8", offered, MIMEXML},
+		{"text/*;q=0.3, text/plain;q=0.9", offered, MIMEPlain},
+		{"*/*", offered, MIMEJSON},
+		// 明确列出的格式优先于通配符
+		{"*/*, text/html", offered, MIMEHTML},
+		// 更具体的 q=0 排除该格式
+		{"*/*;q=0.5, application/json;q=0", offered, MIMEXML},
+		{"image/png", offered, ""},
+		{"TEXT/PLAIN", offered, MIMEPlain},
+		{"garbage, text/html", offered, MIMEHTML},
+		{"text/html", nil, ""},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest("GET", "/", nil)
+		if tt.accept != "" {
+			req.Header.Set("Accept", tt.accept)
+		}
+		c := newContext(httptest.NewRecorder(), req)
+		if got := c.NegotiateFormat(tt.offered...); got != tt.expected {
+			t.Errorf("Accept %q: expected %q, got %q", tt.accept, tt.expected, got)
+		}
+	}
+}
+
+func TestNegotiate(t *testing.T) {
+	engine := New()
+	engine.GET("/user", func(c *Context) {
+		c.Negotiate(http.StatusOK, Negotiate{
+			Offered:  []string{MIMEJSON, MIMEXML, MIMEHTML, MIMEPlain},
+			Data:     H{"name": "alice"},
+			HTMLData: "<b>alice</b>",
+			TextData: "alice",
+		})
+	})
+
+	tests := []struct {
+		accept      string
+		code        int
+		contentType string
+		body        string
+	}{
+		{"application/json", 200, "application/json", `{"name":"alice"}`},
+		{"application/xml", 200, "application/xml", `<map><name>alice</name></map>`},
+		{"text/html", 200, "text/html", `<b>alice</b>`},
+		{"text/plain", 200, "text/plain", `alice`},
+		{"image/png", 406, "application/json", `{"error":"not acceptable"}`},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest("GET", "/user", nil)
+		req.Header.Set("Accept", tt.accept)
+		w := httptest.NewRecorder()
+		engine.ServeHTTP(w, req)
+
+		if w.Code != tt.code {
+			t.Errorf("%s: expected status %d, got %d", tt.accept, tt.code, w.Code)
+		}
+		if ct := w.Header().Get("Content-Type"); !strings.HasPrefix(ct, tt.contentType) {
+			t.Errorf("%s: expected content type %s, got %s", tt.accept, tt.contentType, ct)
+		}
+		if body := strings.TrimSpace(w.Body.String()); body != tt.body {
+			t.Errorf("%s: expected body %s, got %s", tt.accept, tt.body, body)
+		}
+	}
+}
+
+func TestNegotiate_MoreFormats(t *testing.T) {
+	engine := New()
+	engine.GET("/user", func(c *Context) {
+		c.Negotiate(http.StatusOK, Negotiate{
+			Offered: []string{MIMEJSON, MIMEYAML, MIMEMsgPack, "application/pdf"},
+			Data:    H{"name": "alice"},
+		})
+	})
+
+	tests := []struct {
+		accept      string
+		code        int
+		contentType string
+		body        string
+	}{
+		{"application/yaml", 200, "application/yaml", `name: alice`},
+		// 未设置 MsgPackMarshal 时返回500而不是 panic
+		{"application/msgpack", 500, "application/json", `{"message":"renderer not configured: msgpack"}`},
+		// 没有对应输出方式的格式返回500而不是 panic
+		{"application/pdf", 500, "application/json", `{"error":"unsupported negotiate format: application/pdf"}`},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest("GET", "/user", nil)
+		req.Header.Set("Accept", tt.accept)
+		w := httptest.NewRecorder()
+		engine.ServeHTTP(w, req)
+
+		if w.Code != tt.code {
+			t.Errorf("%s: expected status %d, got %d", tt.accept, tt.code, w.Code)
+		}
+		if ct := w.Header().Get("Content-Type"); !strings.HasPrefix(ct, tt.contentType) {
+			t.Errorf("%s: expected content type %s, got %s", tt.accept, tt.contentType, ct)
+		}
+		if body := strings.TrimSpace(w.Body.String()); body != tt.body {
+			t.Errorf("%s: expected body %s, got %s", tt.accept, tt.body, body)
+		}
+	}
+}
+
+func TestNegotiate_HTMLDoesNotUseData(t *testing.T) {
+	offered := []string{MIMEJSON, MIMEXML, MIMEHTML, MIMEPlain}
+	engine := New()
+	engine.GET("/search", func(c *Context) {
+		c.Negotiate(http.StatusOK, Negotiate{Offered: offered, Data: H{"q": "<script>"}})
+	})
+	engine.GET("/escaped", func(c *Context) {
+		c.Negotiate(http.StatusOK, Negotiate{Offered: offered, HTMLData: H{"q": "<script>"}})
+	})
+
+	// 没有 HTML 数据时不提供 HTML 格式, text/* 选中 text/plain
+	req := httptest.NewRequest("GET", "/search", nil)
+	req.Header.Set("Accept", "text/*")
+	w := httptest.NewRecorder()
+	engine.ServeHTTP(w, req)
+	if ct := w.Header().Get("Content-Type"); !strings.HasPrefix(ct, "text/plain") {
+		t.Errorf("Expected text/plain, got %s", ct)
+	}
+
+	req = httptest.NewRequest("GET", "/search", nil)
+	req.Header.Set("Accept", "text/html")
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, req)
+	if w.Code != http.StatusNotAcceptable || strings.Contains(w.Body.String(), "<script>") {
+		t.Errorf("Expected 406 without HTML data, got %d %s", w.Code, w.Body.String())
+	}
+
+	// 非字符串的 HTMLData 会被转义
+	req = httptest.NewRequest("GET", "/escaped", nil)
+	req.Header.Set("Accept", "text/html")
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, req)
+	if w.Code != http.StatusOK || strings.Contains(w.Body.String(), "<script>") || !strings.Contains(w.Body.String(), "&lt;script&gt;") {
+		t.Errorf("Expected escaped HTML, got %d %s", w.Code, w.Body.String())
+	}
+}
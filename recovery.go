@@ -29,7 +29,11 @@ func Recovery() HandlerFunc {
 			if err := recover(); err != nil {
 				message := fmt.Sprintf("%s", err)
 				log.Printf("%s\n\n", trace(message))
-				c.Response.Fail(http.StatusInternalServerError, "Internal Server Error")
+				// 已开始写响应体时无法再修改状态码, 只终止处理器链
+				if !c.Response.Written() {
+					c.Response.Fail(http.StatusInternalServerError, "Internal Server Error")
+				}
+				c.Abort()
 			}
 		}()
 
@@ -0,0 +1,161 @@
+package gooo
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// Render 响应格式, 实现该接口即可通过 c.Render 输出自定义格式
+type Render interface {
+	// ContentType 返回响应的 Content-Type
+	ContentType() string
+	// Render 将数据编码写入 w
+	Render(w io.Writer) error
+}
+
+// Marshaler 将数据编码为字节, 用于接入第三方编码库
+type Marshaler func(v any) ([]byte, error)
+
+// 可选格式的编码函数, 默认未设置, 以免框架引入第三方依赖. 使用前按需接入, 如:
+//
+//	gooo.MsgPackMarshal = msgpack.Marshal
+//	gooo.ProtoBufMarshal = func(v any) ([]byte, error) { return proto.Marshal(v.(proto.Message)) }
+//	gooo.TOMLMarshal = toml.Marshal
+var (
+	MsgPackMarshal  Marshaler
+	ProtoBufMarshal Marshaler
+	TOMLMarshal     Marshaler
+)
+
+// ErrRendererNotConfigured 可选格式的编码函数未设置
+var ErrRendererNotConfigured = errors.New("renderer not configured")
+
+// XMLRender 输出 XML, H 会被编码为 <map> 元素
+type XMLRender struct {
+	Data any
+}
+
+func (XMLRender) ContentType() string { return MIMEXML }
+
+func (r XMLRender) Render(w io.Writer) error {
+	return xml.NewEncoder(w).Encode(r.Data)
+}
+
+// YAMLRender 输出 YAML, 字段名优先使用 yaml 标签, 其次是 json 标签.
+// 使用内置的简易编码器, 只支持输出所需的子集(见 marshalYAML), 不支持锚点、多行块字符串等特性
+type YAMLRender struct {
+	Data any
+}
+
+func (YAMLRender) ContentType() string { return MIMEYAML }
+
+func (r YAMLRender) Render(w io.Writer) error {
+	data, err := marshalYAML(r.Data)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(data)
+	return err
+}
+
+// MsgPackRender 输出 MessagePack, 需先设置 MsgPackMarshal
+type MsgPackRender struct {
+	Data any
+}
+
+func (MsgPackRender) ContentType() string { return MIMEMsgPack }
+
+func (r MsgPackRender) Render(w io.Writer) error {
+	return renderMarshaled(w, MsgPackMarshal, "msgpack", r.Data)
+}
+
+// ProtoBufRender 输出 Protocol Buffers, 需先设置 ProtoBufMarshal
+type ProtoBufRender struct {
+	Data any
+}
+
+func (ProtoBufRender) ContentType() string { return MIMEProtoBuf }
+
+func (r ProtoBufRender) Render(w io.Writer) error {
+	return renderMarshaled(w, ProtoBufMarshal, "protobuf", r.Data)
+}
+
+// TOMLRender 输出 TOML, 需先设置 TOMLMarshal
+type TOMLRender struct {
+	Data any
+}
+
+func (TOMLRender) ContentType() string { return MIMETOML }
+
+func (r TOMLRender) Render(w io.Writer) error {
+	return renderMarshaled(w, TOMLMarshal, "toml", r.Data)
+}
+
+// renderMarshaled 使用可选的编码函数输出
+func renderMarshaled(w io.Writer, marshal Marshaler, name string, data any) error {
+	if marshal == nil {
+		return fmt.Errorf("%w: %s", ErrRendererNotConfigured, name)
+	}
+	b, err := marshal(data)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(b)
+	return err
+}
+
+// Render 先将数据编码到缓冲区, 成功后再设置 Content-Type 和状态码并写入,
+// 编码失败时返回500错误响应, 不会输出不完整的内容
+func (r *Response) Render(code int, rd Render) {
+	var buf bytes.Buffer
+	if err := rd.Render(&buf); err != nil {
+		DebugPrint("响应编码失败: %v", err)
+		r.Fail(http.StatusInternalServerError, err.Error())
+		return
+	}
+	r.SetContentType(rd.ContentType())
+	r.Status(code)
+	if bodyAllowedForStatus(code) {
+		r.Writer.Write(buf.Bytes())
+	}
+}
+
+// bodyAllowedForStatus 判断状态码是否允许响应体(1xx / 204 / 304 不允许)
+func bodyAllowedForStatus(code int) bool {
+	switch {
+	case code >= 100 && code <= 199:
+		return false
+	case code == http.StatusNoContent, code == http.StatusNotModified:
+		return false
+	}
+	return true
+}
+
+// Render 使用指定格式输出
+func (c *Context) Render(code int, r Render) {
+	c.Response.Render(code, r)
+}
+
+// YAML 以 YAML 格式输出
+func (c *Context) YAML(code int, obj any) {
+	c.Render(code, YAMLRender{Data: obj})
+}
+
+// MsgPack 以 MessagePack 格式输出, 需先设置 MsgPackMarshal
+func (c *Context) MsgPack(code int, obj any) {
+	c.Render(code, MsgPackRender{Data: obj})
+}
+
+// ProtoBuf 以 Protocol Buffers 格式输出, 需先设置 ProtoBufMarshal
+func (c *Context) ProtoBuf(code int, obj any) {
+	c.Render(code, ProtoBufRender{Data: obj})
+}
+
+// TOML 以 TOML 格式输出, 需先设置 TOMLMarshal
+func (c *Context) TOML(code int, obj any) {
+	c.Render(code, TOMLRender{Data: obj})
+}
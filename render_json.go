@@ -0,0 +1,264 @@
+package gooo
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"unicode/utf8"
+)
+
+// defaultSecureJSONPrefix Engine.SecureJSONPrefix 的默认值
+const defaultSecureJSONPrefix = "while(1);"
+
+// ErrInvalidCallback JSONP 回调函数名不合法
+var ErrInvalidCallback = errors.New("invalid jsonp callback")
+
+// jsonpCallbackPattern 合法的回调函数名, 如 cb / jQuery123_456 / app.handlers.load
+var jsonpCallbackPattern = regexp.MustCompile(`^[a-zA-Z_$][a-zA-Z0-9_$]*(\.[a-zA-Z_$][a-zA-Z0-9_$]*)*$`)
+
+// maxJSONPCallbackLength 回调函数名的最大长度
+const maxJSONPCallbackLength = 128
+
+// JSONRender 输出 JSON, 转义 HTML 字符
+type JSONRender struct {
+	Data any
+}
+
+func (JSONRender) ContentType() string { return "application/json" }
+
+func (r JSONRender) Render(w io.Writer) error {
+	return json.NewEncoder(w).Encode(r.Data)
+}
+
+// IndentedJSONRender 输出缩进格式的 JSON, 便于阅读和调试
+type IndentedJSONRender struct {
+	Data any
+}
+
+func (IndentedJSONRender) ContentType() string { return "application/json" }
+
+func (r IndentedJSONRender) Render(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "    ")
+	return enc.Encode(r.Data)
+}
+
+// SecureJSONRender 在 JSON 前加上前缀(如 while(1);), 防止 JSON 劫持, 客户端需先去掉前缀再解析
+type SecureJSONRender struct {
+	Prefix string
+	Data   any
+}
+
+func (SecureJSONRender) ContentType() string { return "application/json" }
+
+func (r SecureJSONRender) Render(w io.Writer) error {
+	b, err := json.Marshal(r.Data)
+	if err != nil {
+		return err
+	}
+	if _, err := io.WriteString(w, r.Prefix); err != nil {
+		return err
+	}
+	_, err = w.Write(b)
+	return err
+}
+
+// JSONPRender 输出 JSONP, 回调函数名为空时输出普通 JSON
+type JSONPRender struct {
+	Callback string
+	Data     any
+}
+
+func (r JSONPRender) ContentType() string {
+	if r.Callback == "" {
+		return "application/json"
+	}
+	return "application/javascript"
+}
+
+func (r JSONPRender) Render(w io.Writer) error {
+	b, err := json.Marshal(r.Data)
+	if err != nil {
+		return err
+	}
+	if r.Callback == "" {
+		_, err = w.Write(b)
+		return err
+	}
+	if !validCallback(r.Callback) {
+		return fmt.Errorf("%w: %q", ErrInvalidCallback, r.Callback)
+	}
+	// 开头的空注释用于防御 Rosetta Flash 攻击
+	_, err = fmt.Fprintf(w, "/**/ typeof %s === 'function' && %s(%s);", r.Callback, r.Callback, b)
+	return err
+}
+
+// validCallback 校验回调函数名, 只允许 JavaScript 标识符和点分隔的属性访问
+func validCallback(callback string) bool {
+	return len(callback) <= maxJSONPCallbackLength && jsonpCallbackPattern.MatchString(callback)
+}
+
+// AsciiJSONRender 输出只含 ASCII 字符的 JSON, 非 ASCII 字符转义为 \uXXXX
+type AsciiJSONRender struct {
+	Data any
+}
+
+func (AsciiJSONRender) ContentType() string { return "application/json" }
+
+func (r AsciiJSONRender) Render(w io.Writer) error {
+	b, err := json.Marshal(r.Data)
+	if err != nil {
+		return err
+	}
+	var buf bytes.Buffer
+	buf.Grow(len(b))
+	for len(b) > 0 {
+		c, size := utf8.DecodeRune(b)
+		switch {
+		case c < utf8.RuneSelf:
+			buf.WriteByte(b[0])
+		case c > 0xFFFF:
+			// 超出基本多文种平面的字符使用代理对
+			c -= 0x10000
+			fmt.Fprintf(&buf, `\u%04x\u%04x`, 0xD800+(c>>10), 0xDC00+(c&0x3FF))
+		default:
+			fmt.Fprintf(&buf, `\u%04x`, c)
+		}
+		b = b[size:]
+	}
+	_, err = w.Write(buf.Bytes())
+	return err
+}
+
+// PureJSONRender 输出 JSON, 不转义 <, >, & 等 HTML 字符
+type PureJSONRender struct {
+	Data any
+}
+
+func (PureJSONRender) ContentType() string { return "application/json" }
+
+func (r PureJSONRender) Render(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+	return enc.Encode(r.Data)
+}
+
+// IndentedJSON 以缩进格式输出 JSON
+func (c *Context) IndentedJSON(code int, obj any) {
+	c.Render(code, IndentedJSONRender{Data: obj})
+}
+
+// SecureJSON 输出带 Engine.SecureJSONPrefix 前缀的 JSON
+func (c *Context) SecureJSON(code int, obj any) {
+	prefix := defaultSecureJSONPrefix
+	if c.engine != nil {
+		prefix = c.engine.SecureJSONPrefix
+	}
+	c.Render(code, SecureJSONRender{Prefix: prefix, Data: obj})
+}
+
+// JSONP 按查询参数 callback 输出 JSONP, 没有 callback 时输出普通 JSON, callback 不合法时返回400
+func (c *Context) JSONP(code int, obj any) {
+	callback := c.Query("callback")
+	if callback != "" && !validCallback(callback) {
+		c.Response.Error(http.StatusBadRequest, ErrInvalidCallback)
+		c.Abort()
+		return
+	}
+	if callback != "" {
+		c.SetHeader("X-Content-Type-Options", "nosniff")
+	}
+	c.Render(code, JSONPRender{Callback: callback, Data: obj})
+}
+
+// AsciiJSON 输出只含 ASCII 字符的 JSON
+func (c *Context) AsciiJSON(code int, obj any) {
+	c.Render(code, AsciiJSONRender{Data: obj})
+}
+
+// PureJSON 输出不转义 HTML 字符的 JSON
+func (c *Context) PureJSON(code int, obj any) {
+	c.Render(code, PureJSONRender{Data: obj})
+}
+
+// JSONArrayWriter 以 JSON 数组的形式逐个输出元素, 用于无法一次性放入内存的大结果集.
+// 响应头在第一个元素编码成功(或 Close)时才发送, 因此第一个元素编码失败时仍可返回错误响应;
+// 之后的元素编码失败时状态码已发送, 调用方应停止输出.
+// 数组开始前响应已由其他方式写出(如上述错误响应)时, Close 不再输出任何内容, 因此可以 defer Close.
+type JSONArrayWriter struct {
+	c       *Context
+	code    int
+	started bool
+	closed  bool
+	count   int
+}
+
+// JSONArray 返回流式 JSON 数组输出器, 输出完毕后必须调用 Close
+func (c *Context) JSONArray(code int) *JSONArrayWriter {
+	return &JSONArrayWriter{c: c, code: code}
+}
+
+// Write 编码并输出一个元素, 编码失败时不输出任何内容
+func (w *JSONArrayWriter) Write(v any) error {
+	if w.closed {
+		return errors.New("json array writer is closed")
+	}
+	if !w.started && w.c.Response.Written() {
+		return errors.New("json array writer: response already written")
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	if err := w.start(); err != nil {
+		return err
+	}
+	if w.count > 0 {
+		b = append([]byte{','}, b...)
+	}
+	if _, err := w.c.Response.Writer.Write(b); err != nil {
+		return err
+	}
+	w.count++
+	return nil
+}
+
+// Flush 将已输出的元素立即发送给客户端
+func (w *JSONArrayWriter) Flush() {
+	if f, ok := w.c.Response.Writer.(http.Flusher); ok && w.started {
+		f.Flush()
+	}
+}
+
+// Close 结束数组, 没有元素时输出 []; 数组开始前响应已被写出时不输出
+func (w *JSONArrayWriter) Close() error {
+	if w.closed {
+		return nil
+	}
+	if !w.started && w.c.Response.Written() {
+		w.closed = true
+		return nil
+	}
+	if err := w.start(); err != nil {
+		return err
+	}
+	w.closed = true
+	_, err := w.c.Response.Writer.Write([]byte("]"))
+	return err
+}
+
+// start 发送响应头和数组开头
+func (w *JSONArrayWriter) start() error {
+	if w.started {
+		return nil
+	}
+	w.started = true
+	w.c.SetContentType("application/json")
+	w.c.Status(w.code)
+	_, err := w.c.Response.Writer.Write([]byte("["))
+	return err
+}
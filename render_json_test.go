@@ -0,0 +1,158 @@
+package gooo
+
+import (
+	"errors"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestJSONVariants(t *testing.T) {
+	data := H{"html": "<b>&</b>", "name": "中文😀"}
+	engine := New()
+	engine.GET("/json", func(c *Context) { c.JSON(http.StatusOK, data) })
+	engine.GET("/indented", func(c *Context) { c.IndentedJSON(http.StatusOK, H{"a": 1}) })
+	engine.GET("/secure", func(c *Context) { c.SecureJSON(http.StatusOK, []int{1, 2}) })
+	engine.GET("/jsonp", func(c *Context) { c.JSONP(http.StatusOK, H{"a": 1}) })
+	engine.GET("/ascii", func(c *Context) { c.AsciiJSON(http.StatusOK, data) })
+	engine.GET("/pure", func(c *Context) { c.PureJSON(http.StatusOK, data) })
+	engine.GET("/error", func(c *Context) { c.JSON(http.StatusOK, H{"nan": math.NaN()}) })
+
+	tests := []struct {
+		path        string
+		code        int
+		contentType string
+		body        string
+	}{
+		{"/json", 200, "application/json", `{"html":"\u003cb\u003e\u0026\u003c/b\u003e","name":"中文😀"}` + "\n"},
+		{"/indented", 200, "application/json", "{\n    \"a\": 1\n}\n"},
+		{"/secure", 200, "application/json", "while(1);[1,2]"},
+		{"/jsonp", 200, "application/json", `{"a":1}`},
+		{"/jsonp?callback=app.load_1", 200, "application/javascript",
+			`/**/ typeof app.load_1 === 'function' && app.load_1({"a":1});`},
+		{"/jsonp?callback=alert(1)//", 400, "application/json", `{"error":"invalid jsonp callback"}` + "\n"},
+		{"/ascii", 200, "application/json",
+			`{"html":"\u003cb\u003e\u0026\u003c/b\u003e","name":"\u4e2d\u6587\ud83d\ude00"}`},
+		{"/pure", 200, "application/json", `{"html":"<b>&</b>","name":"中文😀"}` + "\n"},
+		// 编码失败在发送状态码之前发现, 返回500
+		{"/error", 500, "application/json", `{"message":"json: unsupported value: NaN"}` + "\n"},
+	}
+	for _, tt := range tests {
+		w := httptest.NewRecorder()
+		engine.ServeHTTP(w, httptest.NewRequest("GET", tt.path, nil))
+		if w.Code != tt.code {
+			t.Errorf("%s: expected status %d, got %d", tt.path, tt.code, w.Code)
+		}
+		if ct := w.Header().Get("Content-Type"); ct != tt.contentType {
+			t.Errorf("%s: expected content type %s, got %s", tt.path, tt.contentType, ct)
+		}
+		if w.Body.String() != tt.body {
+			t.Errorf("%s: expected body %q, got %q", tt.path, tt.body, w.Body.String())
+		}
+	}
+}
+
+func TestSecureJSONPrefix(t *testing.T) {
+	engine := New()
+	engine.SecureJSONPrefix = ")]}',\n"
+	engine.GET("/secure", func(c *Context) { c.SecureJSON(http.StatusOK, H{"a": 1}) })
+
+	w := httptest.NewRecorder()
+	engine.ServeHTTP(w, httptest.NewRequest("GET", "/secure", nil))
+	if w.Body.String() != ")]}',\n{\"a\":1}" {
+		t.Errorf("Unexpected body %q", w.Body.String())
+	}
+}
+
+func TestValidCallback(t *testing.T) {
+	for _, cb := range []string{"cb", "$", "_cb1", "jQuery123_456", "app.handlers.load"} {
+		if !validCallback(cb) {
+			t.Errorf("%q should be valid", cb)
+		}
+	}
+	for _, cb := range []string{"1cb", "a.", ".a", "a..b", "a b", "a;b", "a(1)", "a[0]", strings.Repeat("a", 200)} {
+		if validCallback(cb) {
+			t.Errorf("%q should be invalid", cb)
+		}
+	}
+}
+
+func TestJSONArrayWriter(t *testing.T) {
+	engine := New()
+	engine.GET("/items", func(c *Context) {
+		arr := c.JSONArray(http.StatusOK)
+		for i := 1; i <= 3; i++ {
+			if err := arr.Write(H{"id": i}); err != nil {
+				t.Fatal(err)
+			}
+			arr.Flush()
+		}
+		arr.Close()
+	})
+	engine.GET("/empty", func(c *Context) {
+		c.JSONArray(http.StatusOK).Close()
+	})
+	engine.GET("/bad-first", func(c *Context) {
+		arr := c.JSONArray(http.StatusOK)
+		if err := arr.Write(math.Inf(1)); err != nil {
+			c.Response.Error(http.StatusInternalServerError, err)
+			return
+		}
+		arr.Close()
+	})
+	engine.GET("/bad-first-deferred", func(c *Context) {
+		arr := c.JSONArray(http.StatusOK)
+		defer arr.Close()
+		if err := arr.Write(math.Inf(1)); err != nil {
+			c.Response.Error(http.StatusInternalServerError, err)
+			if err := arr.Write(1); err == nil {
+				t.Error("Write after an error response should fail")
+			}
+			return
+		}
+	})
+	engine.GET("/bad-later", func(c *Context) {
+		arr := c.JSONArray(http.StatusOK)
+		arr.Write(1)
+		if err := arr.Write(math.NaN()); err == nil {
+			t.Error("Expected encode error")
+		}
+		arr.Write(2)
+		arr.Close()
+		if err := arr.Write(3); err == nil {
+			t.Error("Write after Close should fail")
+		}
+	})
+
+	tests := []struct {
+		path string
+		code int
+		body string
+	}{
+		{"/items", 200, `[{"id":1},{"id":2},{"id":3}]`},
+		{"/empty", 200, `[]`},
+		// 第一个元素编码失败时状态码尚未发送
+		{"/bad-first", 500, `{"error":"json: unsupported value: +Inf"}` + "\n"},
+		// 已输出错误响应时 Close 不再追加数组
+		{"/bad-first-deferred", 500, `{"error":"json: unsupported value: +Inf"}` + "\n"},
+		// 失败的元素被跳过, 输出仍是合法的数组
+		{"/bad-later", 200, `[1,2]`},
+	}
+	for _, tt := range tests {
+		w := httptest.NewRecorder()
+		engine.ServeHTTP(w, httptest.NewRequest("GET", tt.path, nil))
+		if w.Code != tt.code || w.Body.String() != tt.body {
+			t.Errorf("%s: expected %d %q, got %d %q", tt.path, tt.code, tt.body, w.Code, w.Body.String())
+		}
+	}
+}
+
+func TestJSONPRender_InvalidCallback(t *testing.T) {
+	var sb strings.Builder
+	err := JSONPRender{Callback: "x<y", Data: 1}.Render(&sb)
+	if !errors.Is(err, ErrInvalidCallback) || sb.Len() != 0 {
+		t.Errorf("Expected ErrInvalidCallback without output, got %v %q", err, sb.String())
+	}
+}
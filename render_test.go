@@ -0,0 +1,209 @@
+package gooo
+
+import (
+	"errors"
+	"io"
+	"math"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+type yamlAddress struct {
+	City string `yaml:"city"`
+	Zip  string `json:"zip,omitempty"`
+}
+
+type yamlMeta struct {
+	Version int `yaml:"version"`
+}
+
+type yamlUser struct {
+	yamlMeta
+	Name     string            `yaml:"name"`
+	Tags     []string          `yaml:"tags"`
+	Address  yamlAddress       `yaml:"address"`
+	Others   []yamlAddress     `yaml:"others"`
+	Labels   map[string]string `yaml:"labels"`
+	Empty    []int             `yaml:"empty"`
+	Created  time.Time         `yaml:"created"`
+	IP       net.IP            `yaml:"ip"`
+	Note     *string           `yaml:"note"`
+	Secret   string            `yaml:"-"`
+	Nickname string            `yaml:"nickname,omitempty"`
+}
+
+func TestMarshalYAML(t *testing.T) {
+	u := yamlUser{
+		yamlMeta: yamlMeta{Version: 2},
+		Name:     "alice",
+		Tags:     []string{"go", "true", "a: b"},
+		Address:  yamlAddress{City: "Paris", Zip: "75001"},
+		Others:   []yamlAddress{{City: "Lyon"}, {City: "Nice", Zip: "06000"}},
+		Labels:   map[string]string{"team": "web", "env": ""},
+		Created:  time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+		IP:       net.ParseIP("10.0.0.1"),
+		Secret:   "x",
+	}
+	expected := `version: 2
+name: alice
+tags:
+  - go
+  - "true"
+  - "a: b"
+address:
+  city: Paris
+  zip: "75001"
+others:
+  - city: Lyon
+  - city: Nice
+    zip: "06000"
+labels:
+  env: ""
+  team: web
+empty: []
+created: 2024-01-02T03:04:05Z
+ip: 10.0.0.1
+note: null
+`
+	out, err := marshalYAML(u)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(out) != expected {
+		t.Errorf("Unexpected YAML:\n%s\nwant:\n%s", out, expected)
+	}
+}
+
+func TestMarshalYAML_Scalars(t *testing.T) {
+	tests := []struct {
+		value    any
+		expected string
+	}{
+		{nil, "null"},
+		{true, "true"},
+		{-3, "-3"},
+		{1.5, "1.5"},
+		{math.Inf(-1), "-.inf"},
+		{"", `""`},
+		{"123", `"123"`},
+		{"0x1F", `"0x1F"`},
+		{"null", `"null"`},
+		{"- item", `"- item"`},
+		{"line\nbreak", `"line\nbreak"`},
+		{"中文", "中文"},
+		// 会被 YAML 解析为时间戳、浮点数、整数或合并键的字符串需加引号
+		{"2024-01-01", `"2024-01-01"`},
+		{"2024-01-01T10:00:00Z", `"2024-01-01T10:00:00Z"`},
+		{".inf", `".inf"`},
+		{"-.Inf", `"-.Inf"`},
+		{".NaN", `".NaN"`},
+		{".nan", `".nan"`},
+		{"1_000", `"1_000"`},
+		{"0b101", `"0b101"`},
+		{"190:20:30", `"190:20:30"`},
+		{"<<", `"<<"`},
+		{"=", `"="`},
+		{"2024", `"2024"`},
+		{"v1.2", "v1.2"},
+		{"a=b", "a=b"},
+		{[]byte("hi"), "aGk="},
+		{[][]int{{1, 2}, {3}}, "- - 1\n  - 2\n- - 3"},
+		{H{}, "{}"},
+	}
+	for _, tt := range tests {
+		out, err := marshalYAML(tt.value)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := strings.TrimSuffix(string(out), "\n"); got != tt.expected {
+			t.Errorf("marshalYAML(%#v) = %q, want %q", tt.value, got, tt.expected)
+		}
+	}
+
+	if _, err := marshalYAML(H{"fn": func() {}}); err == nil {
+		t.Error("Expected error for unsupported type")
+	}
+}
+
+// csvRender 自定义格式示例
+type csvRender struct {
+	rows [][]string
+}
+
+func (csvRender) ContentType() string { return "text/csv" }
+
+func (r csvRender) Render(w io.Writer) error {
+	for _, row := range r.rows {
+		if _, err := io.WriteString(w, strings.Join(row, ",")+"\n"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func TestContext_Render(t *testing.T) {
+	MsgPackMarshal = func(v any) ([]byte, error) {
+		if v == nil {
+			return nil, errors.New("nil value")
+		}
+		return []byte{0x81}, nil
+	}
+	defer func() { MsgPackMarshal = nil }()
+
+	engine := New()
+	engine.GET("/csv", func(c *Context) {
+		c.Render(http.StatusOK, csvRender{rows: [][]string{{"a", "b"}, {"1", "2"}}})
+	})
+	engine.GET("/yaml", func(c *Context) {
+		c.YAML(http.StatusCreated, H{"name": "alice"})
+	})
+	engine.GET("/xml", func(c *Context) {
+		c.XML(http.StatusOK, H{"name": "alice"})
+	})
+	engine.GET("/msgpack", func(c *Context) {
+		c.MsgPack(http.StatusOK, H{"a": 1})
+	})
+	engine.GET("/msgpack-error", func(c *Context) {
+		c.SetHeader("X-Before", "1")
+		c.MsgPack(http.StatusOK, nil)
+	})
+	engine.GET("/protobuf", func(c *Context) {
+		c.ProtoBuf(http.StatusOK, H{})
+	})
+	engine.GET("/nocontent", func(c *Context) {
+		c.YAML(http.StatusNoContent, H{"ignored": true})
+	})
+
+	tests := []struct {
+		path        string
+		code        int
+		contentType string
+		body        string
+	}{
+		{"/csv", 200, "text/csv", "a,b\n1,2\n"},
+		{"/yaml", 201, "application/yaml", "name: alice\n"},
+		{"/xml", 200, "application/xml", "<map><name>alice</name></map>"},
+		{"/msgpack", 200, "application/msgpack", "\x81"},
+		// 编码失败时返回500, 而不是以200输出不完整的内容
+		{"/msgpack-error", 500, "application/json", `{"message":"nil value"}` + "\n"},
+		{"/protobuf", 500, "application/json", `{"message":"renderer not configured: protobuf"}` + "\n"},
+		{"/nocontent", 204, "application/yaml", ""},
+	}
+	for _, tt := range tests {
+		w := httptest.NewRecorder()
+		engine.ServeHTTP(w, httptest.NewRequest("GET", tt.path, nil))
+		if w.Code != tt.code {
+			t.Errorf("%s: expected status %d, got %d", tt.path, tt.code, w.Code)
+		}
+		if ct := w.Header().Get("Content-Type"); ct != tt.contentType {
+			t.Errorf("%s: expected content type %s, got %s", tt.path, tt.contentType, ct)
+		}
+		if w.Body.String() != tt.body {
+			t.Errorf("%s: expected body %q, got %q", tt.path, tt.body, w.Body.String())
+		}
+	}
+}
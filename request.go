@@ -0,0 +1,196 @@
+package gooo
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// initQueryCache 解析并缓存查询参数
+func (c *Context) initQueryCache() {
+	if c.queryCache != nil {
+		return
+	}
+	if c.Req != nil && c.Req.URL != nil {
+		c.queryCache = c.Req.URL.Query()
+	} else {
+		c.queryCache = url.Values{}
+	}
+}
+
+// initFormCache 解析并缓存请求体中的表单参数, 不包含查询参数
+func (c *Context) initFormCache() {
+	if c.formCache != nil {
+		return
+	}
+	c.formCache = url.Values{}
+	if c.Req == nil {
+		return
+	}
+	c.limitRequestBody()
+	if err := c.Req.ParseMultipartForm(c.maxMultipartMemory()); err != nil && !errors.Is(err, http.ErrNotMultipart) {
+		DebugPrint("表单解析失败: %v", err)
+	}
+	if c.Req.PostForm != nil {
+		c.formCache = c.Req.PostForm
+	}
+}
+
+// GetQuery 获取查询参数, 第二个返回值表示参数是否存在
+func (c *Context) GetQuery(key string) (string, bool) {
+	if values, ok := c.GetQueryArray(key); ok {
+		return values[0], true
+	}
+	return "", false
+}
+
+// DefaultQuery 获取查询参数, 不存在时返回默认值
+func (c *Context) DefaultQuery(key, defaultValue string) string {
+	if value, ok := c.GetQuery(key); ok {
+		return value
+	}
+	return defaultValue
+}
+
+// QueryArray 获取查询参数的全部值, 如 ?id=1&id=2
+func (c *Context) QueryArray(key string) []string {
+	values, _ := c.GetQueryArray(key)
+	return values
+}
+
+// GetQueryArray 获取查询参数的全部值, 第二个返回值表示参数是否存在
+func (c *Context) GetQueryArray(key string) ([]string, bool) {
+	c.initQueryCache()
+	values, ok := c.queryCache[key]
+	return values, ok && len(values) > 0
+}
+
+// QueryMap 获取 map 形式的查询参数, 如 ?ids[a]=1&ids[b]=2 返回 {"a": "1", "b": "2"}
+func (c *Context) QueryMap(key string) map[string]string {
+	dict, _ := c.GetQueryMap(key)
+	return dict
+}
+
+// GetQueryMap 获取 map 形式的查询参数, 第二个返回值表示是否至少存在一个键
+func (c *Context) GetQueryMap(key string) (map[string]string, bool) {
+	c.initQueryCache()
+	return valuesMap(c.queryCache, key)
+}
+
+// GetPostForm 获取请求体中的表单参数, 第二个返回值表示参数是否存在
+func (c *Context) GetPostForm(key string) (string, bool) {
+	if values, ok := c.GetPostFormArray(key); ok {
+		return values[0], true
+	}
+	return "", false
+}
+
+// DefaultPostForm 获取请求体中的表单参数, 不存在时返回默认值
+func (c *Context) DefaultPostForm(key, defaultValue string) string {
+	if value, ok := c.GetPostForm(key); ok {
+		return value
+	}
+	return defaultValue
+}
+
+// PostFormArray 获取表单参数的全部值
+func (c *Context) PostFormArray(key string) []string {
+	values, _ := c.GetPostFormArray(key)
+	return values
+}
+
+// GetPostFormArray 获取表单参数的全部值, 第二个返回值表示参数是否存在
+func (c *Context) GetPostFormArray(key string) ([]string, bool) {
+	c.initFormCache()
+	values, ok := c.formCache[key]
+	return values, ok && len(values) > 0
+}
+
+// PostFormMap 获取 map 形式的表单参数, 如 user[name]=alice
+func (c *Context) PostFormMap(key string) map[string]string {
+	dict, _ := c.GetPostFormMap(key)
+	return dict
+}
+
+// GetPostFormMap 获取 map 形式的表单参数, 第二个返回值表示是否至少存在一个键
+func (c *Context) GetPostFormMap(key string) (map[string]string, bool) {
+	c.initFormCache()
+	return valuesMap(c.formCache, key)
+}
+
+// valuesMap 收集 key[子键]=值 形式的参数, 每个子键取第一个值
+func valuesMap(values url.Values, key string) (map[string]string, bool) {
+	dict := make(map[string]string)
+	found := false
+	for k, v := range values {
+		if len(v) == 0 || !strings.HasPrefix(k, key+"[") || !strings.HasSuffix(k, "]") {
+			continue
+		}
+		dict[k[len(key)+1:len(k)-1]] = v[0]
+		found = true
+	}
+	return dict, found
+}
+
+// GetRawData 读取并缓存请求体. 请求体只会从连接读取一次,
+// 之后每次调用都会重置 Req.Body, 因此后续的绑定或再次读取仍能拿到完整内容.
+func (c *Context) GetRawData() ([]byte, error) {
+	if c.Req == nil {
+		return nil, nil
+	}
+	if !c.bodyCached {
+		c.limitRequestBody()
+		if c.Req.Body != nil && c.Req.Body != http.NoBody {
+			body, err := io.ReadAll(c.Req.Body)
+			if err != nil {
+				return nil, err
+			}
+			c.body = body
+		}
+		c.bodyCached = true
+	}
+	c.rewindBody()
+	return c.body, nil
+}
+
+// rewindBody 用缓存的请求体重置 Req.Body
+func (c *Context) rewindBody() {
+	if len(c.body) == 0 {
+		c.Req.Body = http.NoBody
+		return
+	}
+	c.Req.Body = io.NopCloser(bytes.NewReader(c.body))
+}
+
+// getJSONParam 从 JSON 请求体的顶层字段中获取参数
+func (c *Context) getJSONParam(key string) (string, bool) {
+	if c.jsonCache == nil {
+		c.jsonCache = make(map[string]json.RawMessage)
+		if c.requestContentType() == MIMEJSON {
+			if body, err := c.GetRawData(); err == nil && len(body) > 0 {
+				if err := json.Unmarshal(body, &c.jsonCache); err != nil {
+					// 请求体不是 JSON 对象时忽略
+					c.jsonCache = make(map[string]json.RawMessage)
+				}
+			}
+		}
+	}
+	raw, ok := c.jsonCache[key]
+	if !ok {
+		return "", false
+	}
+	switch {
+	case string(raw) == "null":
+		return "", true
+	case len(raw) > 0 && raw[0] == '"':
+		var s string
+		if err := json.Unmarshal(raw, &s); err == nil {
+			return s, true
+		}
+	}
+	return string(raw), true
+}
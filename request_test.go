@@ -0,0 +1,140 @@
+package gooo
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestContext_QueryArrayAndMap(t *testing.T) {
+	req := httptest.NewRequest("GET", "/?id=1&id=2&ids[a]=x&ids[b]=y&idsx=z&empty=", nil)
+	c := newContext(httptest.NewRecorder(), req)
+
+	if ids := c.QueryArray("id"); !reflect.DeepEqual(ids, []string{"1", "2"}) {
+		t.Errorf("Unexpected QueryArray: %v", ids)
+	}
+	if id := c.Query("id"); id != "1" {
+		t.Errorf("Expected first value, got %s", id)
+	}
+	if m := c.QueryMap("ids"); !reflect.DeepEqual(m, map[string]string{"a": "x", "b": "y"}) {
+		t.Errorf("Unexpected QueryMap: %v", m)
+	}
+	if _, ok := c.GetQueryMap("missing"); ok {
+		t.Error("Missing map should not exist")
+	}
+
+	// 默认值只在参数不存在时使用, 空值也算存在
+	if v := c.DefaultQuery("empty", "d"); v != "" {
+		t.Errorf("Expected empty value, got %q", v)
+	}
+	if v := c.DefaultQuery("missing", "d"); v != "d" {
+		t.Errorf("Expected default value, got %q", v)
+	}
+}
+
+func TestContext_PostFormArrayAndMap(t *testing.T) {
+	body := "tag=a&tag=b&user[name]=alice&user[age]=30"
+	req := httptest.NewRequest("PUT", "/?q=query", strings.NewReader(body))
+	req.Header.Set("Content-Type", MIMEPOSTForm)
+	c := newContext(httptest.NewRecorder(), req)
+
+	if tags := c.PostFormArray("tag"); !reflect.DeepEqual(tags, []string{"a", "b"}) {
+		t.Errorf("Unexpected PostFormArray: %v", tags)
+	}
+	if m := c.PostFormMap("user"); !reflect.DeepEqual(m, map[string]string{"name": "alice", "age": "30"}) {
+		t.Errorf("Unexpected PostFormMap: %v", m)
+	}
+	// GetPostForm 系列只读取请求体
+	if v := c.DefaultPostForm("q", "none"); v != "none" {
+		t.Errorf("DefaultPostForm should not read query, got %q", v)
+	}
+	// PostForm 与 Req.FormValue 相同, 请求体中没有时读取查询参数
+	if v := c.PostForm("q"); v != "query" {
+		t.Errorf("PostForm should fall back to query, got %q", v)
+	}
+}
+
+func TestContext_PostFormPrefersBody(t *testing.T) {
+	req := httptest.NewRequest("POST", "/?name=query", strings.NewReader("name=body"))
+	req.Header.Set("Content-Type", MIMEPOSTForm)
+	c := newContext(httptest.NewRecorder(), req)
+	if v := c.PostForm("name"); v != "body" {
+		t.Errorf("Expected body value, got %q", v)
+	}
+	if v := c.PostForm("missing"); v != "" {
+		t.Errorf("Expected empty value, got %q", v)
+	}
+}
+
+func TestContext_GetParam(t *testing.T) {
+	tests := []struct {
+		name        string
+		method      string
+		contentType string
+		body        string
+		key         string
+		expected    string
+	}{
+		{"query", "GET", "", "", "q", "query"},
+		{"put form", "PUT", MIMEPOSTForm, "name=alice", "name", "alice"},
+		{"patch form over query", "PATCH", MIMEPOSTForm, "q=form", "q", "form"},
+		{"delete query", "DELETE", "", "", "q", "query"},
+		{"delete json", "DELETE", MIMEJSON, `{"id":"42"}`, "id", "42"},
+		{"json string", "POST", MIMEJSON, `{"name":"bob"}`, "name", "bob"},
+		{"json number", "PUT", MIMEJSON, `{"age":30}`, "age", "30"},
+		{"json bool", "PATCH", MIMEJSON + "; charset=utf-8", `{"admin":true}`, "admin", "true"},
+		{"json null", "POST", MIMEJSON, `{"name":null}`, "name", ""},
+		{"json object", "POST", MIMEJSON, `{"addr":{"city":"Paris"}}`, "addr", `{"city":"Paris"}`},
+		{"json fallback to query", "POST", MIMEJSON, `{"name":"bob"}`, "q", "query"},
+		{"json array body", "POST", MIMEJSON, `[1,2]`, "q", "query"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, "/?q=query", strings.NewReader(tt.body))
+			if tt.contentType != "" {
+				req.Header.Set("Content-Type", tt.contentType)
+			}
+			c := newContext(httptest.NewRecorder(), req)
+			if got := c.GetParam(tt.key); got != tt.expected {
+				t.Errorf("GetParam(%q) = %q, want %q", tt.key, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestContext_BodyReuse(t *testing.T) {
+	req := httptest.NewRequest("PUT", "/", strings.NewReader(`{"name":"alice","age":30}`))
+	req.Header.Set("Content-Type", MIMEJSON)
+	c := newContext(httptest.NewRecorder(), req)
+
+	if name := c.GetParam("name"); name != "alice" {
+		t.Fatalf("Expected alice, got %s", name)
+	}
+	// GetParam 读取过请求体后仍可绑定, 且可重复绑定
+	for i := 0; i < 2; i++ {
+		var u bindUser
+		if err := c.ShouldBindJSON(&u); err != nil || u.Name != "alice" || u.Age != 30 {
+			t.Fatalf("Bind #%d failed: %v %+v", i, err, u)
+		}
+	}
+	raw, err := c.GetRawData()
+	if err != nil || string(raw) != `{"name":"alice","age":30}` {
+		t.Fatalf("Unexpected raw body %q: %v", raw, err)
+	}
+	data, _ := io.ReadAll(c.Req.Body)
+	if string(data) != string(raw) {
+		t.Errorf("Req.Body should be readable after GetRawData, got %q", data)
+	}
+
+	// 对象复用时清空缓存
+	c.reset(httptest.NewRecorder(), httptest.NewRequest("GET", "/?name=bob", nil))
+	if name := c.GetParam("name"); name != "bob" {
+		t.Errorf("Expected cache to be reset, got %s", name)
+	}
+	if raw, _ := c.GetRawData(); len(raw) != 0 || c.Req.Body != http.NoBody {
+		t.Errorf("Expected empty body after reset, got %q", raw)
+	}
+}
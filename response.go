@@ -1,7 +1,7 @@
 package gooo
 
 import (
-	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 )
@@ -21,17 +21,20 @@ func (r *Response) SetContentType(value string) {
 	r.SetHeader("Content-Type", value)
 }
 
+// Status 设置状态码. Writer 为 gooo 的 ResponseWriter 时响应头延迟到写入响应体时发送
 func (r *Response) Status(code int) {
 	r.StatusCode = code
 	r.Writer.WriteHeader(code)
 }
 
+// JSON 以 JSON 格式输出, 编码失败时返回500错误响应
 func (r *Response) JSON(code int, obj interface{}) {
-	r.SetContentType("application/json")
-	r.Status(code)
-	if err := json.NewEncoder(r.Writer).Encode(obj); err != nil {
-		http.Error(r.Writer, err.Error(), 500)
-	}
+	r.Render(code, JSONRender{Data: obj})
+}
+
+// XML 以 XML 格式输出, H 会被编码为 <map> 元素
+func (r *Response) XML(code int, obj any) {
+	r.Render(code, XMLRender{Data: obj})
 }
 
 func (r *Response) HTML(code int, html string) {
@@ -56,6 +59,23 @@ func (r *Response) Fail(code int, err string) {
 	r.JSON(code, H{"message": err})
 }
 
+// Written 返回响应头是否已发送.
+// Writer 不是 gooo 的 ResponseWriter 时, Status 会立即发送响应头, 以是否设置过状态码判断
+func (r *Response) Written() bool {
+	if w, ok := r.Writer.(ResponseWriter); ok {
+		return w.Written()
+	}
+	return r.StatusCode != 0
+}
+
+// Size 返回已写入的响应体字节数, Writer 不是 gooo 的 ResponseWriter 时返回 -1
+func (r *Response) Size() int {
+	if w, ok := r.Writer.(ResponseWriter); ok {
+		return w.Size()
+	}
+	return -1
+}
+
 func (r *Response) GetContentType(name string) string {
 	if name == "" {
 		name = "Content-Type"
@@ -81,6 +101,12 @@ func (r *Response) Attachment(filename string) {
 }
 
 // 错误处理优化
+// 校验错误(ValidationErrors)会输出每个字段的错误信息
 func (r *Response) Error(code int, err error) {
+	var verrs ValidationErrors
+	if errors.As(err, &verrs) {
+		r.JSON(code, H{"error": "validation failed", "fields": verrs})
+		return
+	}
 	r.JSON(code, H{"error": err.Error()})
 }
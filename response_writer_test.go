@@ -0,0 +1,117 @@
+package gooo
+
+import (
+	"bytes"
+	"errors"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+)
+
+func TestResponseWriter_DeferredHeader(t *testing.T) {
+	engine := New()
+	engine.GET("/created", func(c *Context) {
+		c.Status(http.StatusCreated)
+		// 写入响应体之前设置的响应头不会丢失
+		c.SetHeader("X-Id", "42")
+		c.Status(http.StatusAccepted)
+		c.Writer.Write([]byte("ok"))
+	})
+	engine.GET("/empty", func(c *Context) {
+		c.Status(http.StatusNoContent)
+		c.SetHeader("X-Empty", "1")
+	})
+
+	w := httptest.NewRecorder()
+	engine.ServeHTTP(w, httptest.NewRequest("GET", "/created", nil))
+	if w.Code != http.StatusAccepted || w.Header().Get("X-Id") != "42" || w.Body.String() != "ok" {
+		t.Errorf("Unexpected response %d %v %q", w.Code, w.Header(), w.Body.String())
+	}
+
+	// 只设置状态码的响应在请求结束时发送
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, httptest.NewRequest("GET", "/empty", nil))
+	if w.Code != http.StatusNoContent || w.Header().Get("X-Empty") != "1" {
+		t.Errorf("Unexpected response %d %v", w.Code, w.Header())
+	}
+}
+
+func TestResponseWriter_StatusAndSize(t *testing.T) {
+	var status, size int
+	var written bool
+	engine := New()
+	engine.Use(func(c *Context) {
+		c.Next()
+		status, size, written = c.GetStatusCode(), c.Response.Size(), c.Response.Written()
+	})
+	engine.GET("/body", func(c *Context) {
+		if c.Response.Written() {
+			t.Error("Written should be false before writing")
+		}
+		c.Writer.Write([]byte("hello"))
+	})
+
+	engine.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/body", nil))
+	if status != http.StatusOK || size != 5 || !written {
+		t.Errorf("Unexpected status=%d size=%d written=%v", status, size, written)
+	}
+}
+
+func TestResponseWriter_RecoveryAfterWrite(t *testing.T) {
+	log.SetOutput(&bytes.Buffer{})
+	defer log.SetOutput(os.Stderr)
+
+	engine := New()
+	engine.Use(Recovery())
+	engine.GET("/partial", func(c *Context) {
+		c.String(http.StatusOK, "partial")
+		panic("boom")
+	})
+	engine.GET("/before", func(c *Context) {
+		c.Status(http.StatusOK)
+		panic("boom")
+	})
+
+	// 已写入响应体时保留原状态码和内容
+	w := httptest.NewRecorder()
+	engine.ServeHTTP(w, httptest.NewRequest("GET", "/partial", nil))
+	if w.Code != http.StatusOK || w.Body.String() != "partial" {
+		t.Errorf("Unexpected response %d %q", w.Code, w.Body.String())
+	}
+
+	// 尚未写入响应体时仍可返回500
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, httptest.NewRequest("GET", "/before", nil))
+	if w.Code != http.StatusInternalServerError {
+		t.Errorf("Expected status 500, got %d", w.Code)
+	}
+}
+
+func TestResponseWriter_Passthrough(t *testing.T) {
+	rec := httptest.NewRecorder()
+	w := &responseWriter{}
+	w.reset(rec)
+
+	w.WriteHeader(http.StatusCreated)
+	w.Flush()
+	if !rec.Flushed || rec.Code != http.StatusCreated || !w.Written() {
+		t.Errorf("Flush should send headers and flush the underlying writer")
+	}
+	// 响应头发送后忽略新的状态码
+	w.WriteHeader(http.StatusInternalServerError)
+	if w.Status() != http.StatusCreated {
+		t.Errorf("Status should not change after headers are sent, got %d", w.Status())
+	}
+
+	if _, _, err := w.Hijack(); err == nil {
+		t.Error("Hijack should fail when the underlying writer does not support it")
+	}
+	if err := w.Push("/app.js", nil); !errors.Is(err, http.ErrNotSupported) {
+		t.Errorf("Expected ErrNotSupported, got %v", err)
+	}
+	if rc := http.NewResponseController(w); rc.Flush() != nil {
+		t.Error("ResponseController should reach the underlying writer via Unwrap")
+	}
+}
@@ -3,6 +3,7 @@ package gooo
 import (
 	"fmt"
 	"net/http"
+	"sort"
 	"strings"
 )
 
@@ -12,9 +13,12 @@ type RouterGroup struct {
 	middlewares []HandlerFunc // 中间件列表
 	parent      *RouterGroup  // 父路由组
 	engine      *Engine       // 所有路由组共享一个Engine实例
+	router      *router       // Host 路由组使用的独立路由表, 为空时使用engine.router
+	host        string        // Host 模式
 }
 
 // Use 注册中间件
+// 处理器链在注册路由时计算, 因此中间件只对之后注册的路由生效
 func (group *RouterGroup) Use(middlewares ...HandlerFunc) {
 	if group.middlewares == nil {
 		group.middlewares = make([]HandlerFunc, 0)
@@ -24,51 +28,199 @@ func (group *RouterGroup) Use(middlewares ...HandlerFunc) {
 			group.middlewares = append(group.middlewares, m)
 		}
 	}
-	// 确保中间件被添加到engine的groups列表中
-	if group.engine != nil {
-		group.engine.groups = append(group.engine.groups, group)
+	// 全局中间件变化时重建404/405及内置静态文件路由的处理器链
+	if group.engine != nil && group == group.engine.RouterGroup {
+		group.engine.rebuildErrorHandlers()
+		group.engine.rebuildStaticHandlers()
 	}
 }
 
+// combineHandlers 按 全局 -> 祖先路由组 -> 当前路由组 -> 路由处理器 的顺序合并处理器链
+func (group *RouterGroup) combineHandlers(handlers []HandlerFunc) []HandlerFunc {
+	groups := make([]*RouterGroup, 0)
+	for g := group; g != nil; g = g.parent {
+		groups = append(groups, g)
+	}
+	size := len(handlers)
+	for _, g := range groups {
+		size += len(g.middlewares)
+	}
+	merged := make([]HandlerFunc, 0, size)
+	for i := len(groups) - 1; i >= 0; i-- {
+		merged = append(merged, groups[i].middlewares...)
+	}
+	return append(merged, handlers...)
+}
+
 // Group 创建新的路由组
 func (group *RouterGroup) Group(prefix string) *RouterGroup {
 	return &RouterGroup{
 		prefix: group.prefix + prefix,
 		parent: group,
 		engine: group.engine,
+		router: group.router,
+		host:   group.host,
 	}
 }
 
-// GET 添加GET路由
-func (group *RouterGroup) GET(path string, handler HandlerFunc) {
+// Route 已注册路由的句柄, 可用于命名路由
+type Route struct {
+	Method string // HTTP方法
+	Host   string // Host 模式, 默认路由表为空
+	Path   string // 完整路径模式(含路由组前缀)
+	name   string
+	engine *Engine
+}
+
+// Name 为路由命名, 供 Engine.URL 和模板函数 url 反向生成路径
+func (r *Route) Name(name string) *Route {
+	if r.engine.namedRoutes == nil {
+		r.engine.namedRoutes = make(map[string]*Route)
+	}
+	if exist, ok := r.engine.namedRoutes[name]; ok && exist != r {
+		panic("重复的路由名称: " + name + " (" + exist.Path + " 与 " + r.Path + ")")
+	}
+	r.name = name
+	r.engine.namedRoutes[name] = r
+	return r
+}
+
+// RouteInfo 路由表中的一条记录
+type RouteInfo struct {
+	Method      string // HTTP方法
+	Host        string // Host 模式, 默认路由表为空
+	Path        string // 路径模式
+	Name        string // 路由名称, 未命名时为空
+	Handler     string // 最终处理器的函数名
+	Middlewares int    // 处理器链中除最终处理器外的中间件数量
+}
+
+// Routes 返回所有已注册路由, 按路径和方法排序
+func (engine *Engine) Routes() []RouteInfo {
+	names := make(map[string]string, len(engine.namedRoutes))
+	for name, route := range engine.namedRoutes {
+		names[route.Host+" "+route.Method+" "+route.Path] = name
+	}
+
+	routes := make([]RouteInfo, 0)
+	collect := func(host string, r *router) {
+		for method, root := range r.roots {
+			root.walk(func(n *node) {
+				routes = append(routes, RouteInfo{
+					Method:      method,
+					Host:        host,
+					Path:        n.pattern,
+					Name:        names[host+" "+method+" "+n.pattern],
+					Handler:     nameOfFunction(n.handlers[len(n.handlers)-1]),
+					Middlewares: len(n.handlers) - 1,
+				})
+			})
+		}
+	}
+	collect("", engine.router)
+	for _, h := range engine.hosts {
+		collect(h.pattern, h.router)
+	}
+	sort.Slice(routes, func(i, j int) bool {
+		if routes[i].Host != routes[j].Host {
+			return routes[i].Host < routes[j].Host
+		}
+		if routes[i].Path != routes[j].Path {
+			return routes[i].Path < routes[j].Path
+		}
+		return routes[i].Method < routes[j].Method
+	})
+	return routes
+}
+
+// Handle 注册指定HTTP方法的路由
+func (group *RouterGroup) Handle(method, path string, handlers ...HandlerFunc) *Route {
+	route, _ := group.handle(method, path, handlers)
+	return route
+}
+
+// handle 注册路由, 同时返回路由节点
+func (group *RouterGroup) handle(method, path string, handlers []HandlerFunc) (*Route, *node) {
+	if len(handlers) == 0 {
+		panic("路由处理器不能为空: " + method + " " + path)
+	}
 	fullPath := group.prefix + path
-	if fullPath[0] != '/' {
+	if fullPath == "" || fullPath[0] != '/' {
 		fullPath = "/" + fullPath
 	}
-	group.engine.router.addRoute("GET", fullPath, handler)
+	r := group.router
+	if r == nil {
+		r = group.engine.router
+	}
+	n := r.addRoute(method, fullPath, group.combineHandlers(handlers))
+	return &Route{Method: method, Host: group.host, Path: fullPath, engine: group.engine}, n
+}
+
+// GET 添加GET路由
+func (group *RouterGroup) GET(path string, handlers ...HandlerFunc) *Route {
+	return group.Handle(http.MethodGet, path, handlers...)
 }
 
 // POST 添加POST路由
-func (group *RouterGroup) POST(path string, handler HandlerFunc) {
-	fullPath := group.prefix + path
-	if fullPath[0] != '/' {
-		fullPath = "/" + fullPath
+func (group *RouterGroup) POST(path string, handlers ...HandlerFunc) *Route {
+	return group.Handle(http.MethodPost, path, handlers...)
+}
+
+// PUT 添加PUT路由
+func (group *RouterGroup) PUT(path string, handlers ...HandlerFunc) *Route {
+	return group.Handle(http.MethodPut, path, handlers...)
+}
+
+// PATCH 添加PATCH路由
+func (group *RouterGroup) PATCH(path string, handlers ...HandlerFunc) *Route {
+	return group.Handle(http.MethodPatch, path, handlers...)
+}
+
+// DELETE 添加DELETE路由
+func (group *RouterGroup) DELETE(path string, handlers ...HandlerFunc) *Route {
+	return group.Handle(http.MethodDelete, path, handlers...)
+}
+
+// HEAD 添加HEAD路由
+func (group *RouterGroup) HEAD(path string, handlers ...HandlerFunc) *Route {
+	return group.Handle(http.MethodHead, path, handlers...)
+}
+
+// OPTIONS 添加OPTIONS路由
+func (group *RouterGroup) OPTIONS(path string, handlers ...HandlerFunc) *Route {
+	return group.Handle(http.MethodOptions, path, handlers...)
+}
+
+// anyMethods Any 注册时覆盖的HTTP方法
+var anyMethods = []string{
+	http.MethodGet, http.MethodPost, http.MethodPut, http.MethodPatch,
+	http.MethodDelete, http.MethodHead, http.MethodOptions,
+	http.MethodConnect, http.MethodTrace,
+}
+
+// Any 为所有常用HTTP方法注册同一路由, 返回GET方法对应的路由句柄
+func (group *RouterGroup) Any(path string, handlers ...HandlerFunc) *Route {
+	var route *Route
+	for _, method := range anyMethods {
+		if r := group.Handle(method, path, handlers...); route == nil {
+			route = r
+		}
 	}
-	group.engine.router.addRoute("POST", fullPath, handler)
+	return route
 }
 
 type router struct {
-	handlers map[string]HandlerFunc
-	roots    map[string]*trie
+	roots     map[string]*node // 每个HTTP方法一棵基数树
+	maxParams int              // 单条路由的最大参数数量, 用于预分配参数切片
 }
 
 func newRouter() *router {
 	return &router{
-		handlers: make(map[string]HandlerFunc),
-		roots:    make(map[string]*trie),
+		roots: make(map[string]*node),
 	}
 }
 
+// parsePattern 将路径模式按 / 切分为片段, 通配符之后的内容被忽略
 func parsePattern(pattern string) []string {
 	vs := strings.Split(pattern, "/")
 	parts := make([]string, 0)
@@ -83,58 +235,123 @@ func parsePattern(pattern string) []string {
 	return parts
 }
 
-func (r *router) addRoute(method string, path string, handler HandlerFunc) {
-	parts := parsePattern(path)
-
+func (r *router) addRoute(method string, path string, handlers []HandlerFunc) *node {
 	// 检查路由冲突
 	if root, ok := r.roots[method]; ok {
-		if conflictNode := root.search(parts); conflictNode != nil {
-			panic(fmt.Sprintf("路由冲突: %s %s 与 %s %s", method, path, method, conflictNode.part))
+		if conflictNode := root.conflict(path); conflictNode != nil {
+			panic(fmt.Sprintf("路由冲突: %s %s 与 %s %s", method, path, method, conflictNode.pattern))
 		}
 	} else {
-		r.roots[method] = &trie{}
+		r.roots[method] = &node{}
 	}
 
-	r.roots[method].insert(path, parts)
-	key := method + "-" + path
-	r.handlers[key] = handler
+	n := r.roots[method].insert(path)
+	n.handlers = handlers
+	if count := countParams(path); count > r.maxParams {
+		r.maxParams = count
+	}
+	return n
 }
 
-func (r *router) getRoute(method string, path string) (*trie, map[string]string) {
+// getRoute 查找路由节点, 路由参数追加到 params (可为 nil)
+func (r *router) getRoute(method string, path string, params *Params) *node {
 	root, ok := r.roots[method]
 	if !ok {
-		return nil, nil
+		return nil
 	}
+	// 静态和参数片段只匹配规范路径(见 node.search), 多余的斜杠或 . / .. 片段交由 RedirectFixedPath 处理;
+	// 通配符的值不受限制, 如 /proxy/*url 可匹配 /proxy/http://x
+	return root.search(path, params)
+}
 
-	parts := parsePattern(path)
-	node := root.search(parts)
-	if node == nil {
-		return nil, nil
+// handler 查找路由并执行注册时计算好的处理器链
+func (r *router) handler(c *Context) {
+	c.handlers = nil
+	if n := r.getRoute(c.Method, c.Path, &c.Params); n != nil {
+		c.handlers = n.handlers
+	} else if c.engine != nil {
+		if location := r.redirectPath(c); location != "" {
+			c.handlers = c.engine.RouterGroup.combineHandlers([]HandlerFunc{redirectHandler(location)})
+			c.index = -1
+			c.Next()
+			return
+		}
+		c.handlers = c.engine.allNoRoute
+		if c.engine.HandleMethodNotAllowed {
+			if allowed := r.allowedMethods(c.Path, c.Method); len(allowed) > 0 {
+				c.SetHeader("Allow", strings.Join(allowed, ", "))
+				c.handlers = c.engine.allNoMethod
+			}
+		}
 	}
+	if c.handlers == nil {
+		c.handlers = []HandlerFunc{notFoundHandler}
+	}
+	c.index = -1
+	c.Next()
+}
 
-	params := make(map[string]string)
-	matchedParts := parsePattern(node.part)
-	for i, part := range matchedParts {
-		if part[0] == ':' {
-			params[part[1:]] = parts[i+1]
+// redirectPath 按 RedirectTrailingSlash / RedirectFixedPath 配置查找规范路径, 找不到时返回空串
+func (r *router) redirectPath(c *Context) string {
+	path := c.Path
+	if c.Method == http.MethodConnect || path == "/" {
+		return ""
+	}
+	candidates := make([]string, 0, 3)
+	if c.engine.RedirectTrailingSlash {
+		candidates = append(candidates, toggleTrailingSlash(path))
+	}
+	if c.engine.RedirectFixedPath {
+		if fixed := cleanPath(path); fixed != path {
+			candidates = append(candidates, fixed)
+			if c.engine.RedirectTrailingSlash && fixed != "/" {
+				candidates = append(candidates, toggleTrailingSlash(fixed))
+			}
 		}
-		if part[0] == '*' && len(part) > 1 {
-			params[part[1:]] = strings.Join(parts[i+1:], "/")
-			break
+	}
+	for _, candidate := range candidates {
+		if r.getRoute(c.Method, candidate, nil) != nil {
+			return candidate
 		}
 	}
-	return node, params
+	return ""
 }
 
-func (r *router) handler(c *Context) {
-	node, params := r.getRoute(c.Method, c.Path)
-	if node != nil {
-		key := c.Method + "-" + node.pattern
-		c.Params = params
-		if handler, ok := r.handlers[key]; ok {
-			handler(c)
-			return
+// redirectHandler 重定向到规范路径, GET 使用301, 其他方法使用308以保留请求方法和请求体
+func redirectHandler(location string) HandlerFunc {
+	return func(c *Context) {
+		code := http.StatusMovedPermanently
+		if c.Method != http.MethodGet {
+			code = http.StatusPermanentRedirect
+		}
+		if c.Req.URL.RawQuery != "" {
+			location += "?" + c.Req.URL.RawQuery
 		}
+		c.Response.Redirect(code, location)
 	}
+}
+
+// notFoundHandler 默认的404响应
+func notFoundHandler(c *Context) {
 	c.String(http.StatusNotFound, "404 NOT FOUND: %s\n", c.Path)
 }
+
+// methodNotAllowedHandler 默认的405响应
+func methodNotAllowedHandler(c *Context) {
+	c.String(http.StatusMethodNotAllowed, "405 METHOD NOT ALLOWED: %s\n", c.Path)
+}
+
+// allowedMethods 返回在其他方法树中能匹配该路径的方法列表(已排序)
+func (r *router) allowedMethods(path, exclude string) []string {
+	allowed := make([]string, 0)
+	for method := range r.roots {
+		if method == exclude {
+			continue
+		}
+		if r.getRoute(method, path, nil) != nil {
+			allowed = append(allowed, method)
+		}
+	}
+	sort.Strings(allowed)
+	return allowed
+}
@@ -126,3 +126,353 @@ func TestEngine_Params(t *testing.T) {
 		}
 	})
 }
+
+func TestEngine_HTTPMethods(t *testing.T) {
+	engine := New()
+	engine.PUT("/res", func(c *Context) { c.String(http.StatusOK, "PUT") })
+	engine.PATCH("/res", func(c *Context) { c.String(http.StatusOK, "PATCH") })
+	engine.DELETE("/res", func(c *Context) { c.String(http.StatusOK, "DELETE") })
+	engine.HEAD("/res", func(c *Context) { c.Status(http.StatusNoContent) })
+	engine.OPTIONS("/res", func(c *Context) { c.String(http.StatusOK, "OPTIONS") })
+	engine.Handle("PROPFIND", "/res", func(c *Context) { c.String(http.StatusOK, "PROPFIND") })
+
+	tests := []struct {
+		method string
+		code   int
+		body   string
+	}{
+		{http.MethodPut, http.StatusOK, "PUT"},
+		{http.MethodPatch, http.StatusOK, "PATCH"},
+		{http.MethodDelete, http.StatusOK, "DELETE"},
+		{http.MethodHead, http.StatusNoContent, ""},
+		{http.MethodOptions, http.StatusOK, "OPTIONS"},
+		{"PROPFIND", http.StatusOK, "PROPFIND"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.method, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(tt.method, "/res", nil)
+			engine.ServeHTTP(w, req)
+
+			if w.Code != tt.code {
+				t.Errorf("Expected status %d, got %d", tt.code, w.Code)
+			}
+			if body := w.Body.String(); body != tt.body {
+				t.Errorf("Expected body '%s', got '%s'", tt.body, body)
+			}
+		})
+	}
+}
+
+func TestEngine_Any(t *testing.T) {
+	engine := New()
+	engine.Group("/v1").Any("/ping", func(c *Context) {
+		c.String(http.StatusOK, c.Method)
+	})
+
+	for _, method := range anyMethods {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(method, "/v1/ping", nil)
+		engine.ServeHTTP(w, req)
+
+		if w.Code != http.StatusOK {
+			t.Errorf("%s: expected status %d, got %d", method, http.StatusOK, w.Code)
+		}
+		if body := w.Body.String(); body != method {
+			t.Errorf("%s: expected body '%s', got '%s'", method, method, body)
+		}
+	}
+}
+
+func TestEngine_HandleWithoutHandler(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("Handle without handlers should panic")
+		}
+	}()
+	New().Handle(http.MethodPut, "/empty")
+}
+
+func TestEngine_MethodNotAllowed(t *testing.T) {
+	engine := New()
+	engine.GET("/items/:id", func(c *Context) { c.String(http.StatusOK, "get") })
+	engine.DELETE("/items/:id", func(c *Context) { c.String(http.StatusOK, "delete") })
+
+	t.Run("default response", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPost, "/items/1", nil)
+		engine.ServeHTTP(w, req)
+
+		if w.Code != http.StatusMethodNotAllowed {
+			t.Errorf("Expected status %d, got %d", http.StatusMethodNotAllowed, w.Code)
+		}
+		if allow := w.Header().Get("Allow"); allow != "DELETE, GET" {
+			t.Errorf("Expected Allow 'DELETE, GET', got '%s'", allow)
+		}
+	})
+
+	t.Run("unknown path stays 404", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPost, "/missing", nil)
+		engine.ServeHTTP(w, req)
+
+		if w.Code != http.StatusNotFound {
+			t.Errorf("Expected status %d, got %d", http.StatusNotFound, w.Code)
+		}
+		if allow := w.Header().Get("Allow"); allow != "" {
+			t.Errorf("Expected no Allow header, got '%s'", allow)
+		}
+	})
+
+	t.Run("custom NoMethod", func(t *testing.T) {
+		engine.NoMethod(func(c *Context) {
+			c.JSON(http.StatusMethodNotAllowed, H{"error": "method not allowed"})
+		})
+		defer engine.NoMethod()
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPut, "/items/1", nil)
+		engine.ServeHTTP(w, req)
+
+		if w.Code != http.StatusMethodNotAllowed {
+			t.Errorf("Expected status %d, got %d", http.StatusMethodNotAllowed, w.Code)
+		}
+		if ct := w.Header().Get("Content-Type"); ct != "application/json" {
+			t.Errorf("Expected JSON content type, got '%s'", ct)
+		}
+		if allow := w.Header().Get("Allow"); allow != "DELETE, GET" {
+			t.Errorf("Expected Allow 'DELETE, GET', got '%s'", allow)
+		}
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		engine.HandleMethodNotAllowed = false
+		defer func() { engine.HandleMethodNotAllowed = true }()
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPost, "/items/1", nil)
+		engine.ServeHTTP(w, req)
+
+		if w.Code != http.StatusNotFound {
+			t.Errorf("Expected status %d, got %d", http.StatusNotFound, w.Code)
+		}
+	})
+}
+
+func TestEngine_NoRoute(t *testing.T) {
+	engine := New()
+	var logged []string
+	engine.Use(func(c *Context) {
+		c.Next()
+		logged = append(logged, c.Path)
+	})
+	engine.GET("/exists", func(c *Context) { c.String(http.StatusOK, "ok") })
+
+	engine.NoRoute(
+		func(c *Context) {
+			c.SetHeader("X-Not-Found", "1")
+			c.Next()
+		},
+		func(c *Context) {
+			c.JSON(http.StatusNotFound, H{"error": "not found", "path": c.Path})
+		},
+	)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/nope", nil)
+	engine.ServeHTTP(w, req)
+
+	if w.Code != http.StatusNotFound {
+		t.Errorf("Expected status %d, got %d", http.StatusNotFound, w.Code)
+	}
+	if ct := w.Header().Get("Content-Type"); ct != "application/json" {
+		t.Errorf("Expected JSON content type, got '%s'", ct)
+	}
+	if w.Header().Get("X-Not-Found") != "1" {
+		t.Error("NoRoute handler chain not fully executed")
+	}
+	if len(logged) != 1 || logged[0] != "/nope" {
+		t.Errorf("Global middleware should run for NoRoute, got %v", logged)
+	}
+}
+
+func TestEngine_RoutePriority(t *testing.T) {
+	engine := New()
+	engine.GET("/users/:id", func(c *Context) {
+		c.String(http.StatusOK, "user "+c.Param("id"))
+	})
+	engine.GET("/users/me", func(c *Context) {
+		c.String(http.StatusOK, "me")
+	})
+
+	for path, expected := range map[string]string{"/users/me": "me", "/users/7": "user 7"} {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", path, nil)
+		engine.ServeHTTP(w, req)
+
+		if body := w.Body.String(); body != expected {
+			t.Errorf("%s: expected '%s', got '%s'", path, expected, body)
+		}
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("registering /users/:name should conflict with /users/:id")
+		}
+	}()
+	engine.GET("/users/:name", func(c *Context) {})
+}
+
+func TestEngine_ParamConstraints(t *testing.T) {
+	engine := New()
+	engine.GET("/orders/:id<int>", func(c *Context) {
+		id, err := c.ParamInt("id")
+		if err != nil {
+			c.String(http.StatusBadRequest, err.Error())
+			return
+		}
+		c.String(http.StatusOK, "order %d", id)
+	})
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/orders/15", nil)
+	engine.ServeHTTP(w, req)
+	if body := w.Body.String(); body != "order 15" {
+		t.Errorf("Expected 'order 15', got '%s'", body)
+	}
+
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest("GET", "/orders/abc", nil)
+	engine.ServeHTTP(w, req)
+	if w.Code != http.StatusNotFound {
+		t.Errorf("Expected status %d, got %d", http.StatusNotFound, w.Code)
+	}
+}
+
+func listUsers(c *Context) {}
+
+func TestEngine_Routes(t *testing.T) {
+	engine := New()
+	engine.Use(func(c *Context) { c.Next() })
+	api := engine.Group("/api")
+	api.Use(func(c *Context) { c.Next() })
+	api.GET("/users", listUsers).Name("users")
+	api.DELETE("/users/:id", func(c *Context) {}, func(c *Context) {})
+
+	routes := engine.Routes()
+	if len(routes) != 2 {
+		t.Fatalf("expected 2 routes, got %d: %+v", len(routes), routes)
+	}
+
+	first := routes[0]
+	if first.Method != "GET" || first.Path != "/api/users" || first.Name != "users" {
+		t.Errorf("unexpected first route: %+v", first)
+	}
+	if first.Handler != "github.com/hookrock/gooo.listUsers" {
+		t.Errorf("unexpected handler name: %s", first.Handler)
+	}
+	if first.Middlewares != 2 {
+		t.Errorf("expected 2 middlewares, got %d", first.Middlewares)
+	}
+
+	second := routes[1]
+	if second.Method != "DELETE" || second.Path != "/api/users/:id" || second.Middlewares != 3 {
+		t.Errorf("unexpected second route: %+v", second)
+	}
+}
+
+func TestEngine_RedirectTrailingSlash(t *testing.T) {
+	engine := New()
+	engine.GET("/users", func(c *Context) { c.String(http.StatusOK, "users") })
+	engine.POST("/dirs/", func(c *Context) { c.String(http.StatusOK, "dirs") })
+
+	tests := []struct {
+		method   string
+		path     string
+		code     int
+		location string
+	}{
+		{"GET", "/users/?page=2", http.StatusMovedPermanently, "/users?page=2"},
+		{"POST", "/dirs", http.StatusPermanentRedirect, "/dirs/"},
+		{"GET", "/users", http.StatusOK, ""},
+		{"GET", "/users//", http.StatusNotFound, ""},
+	}
+	for _, tt := range tests {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(tt.method, tt.path, nil)
+		engine.ServeHTTP(w, req)
+
+		if w.Code != tt.code {
+			t.Errorf("%s %s: expected status %d, got %d", tt.method, tt.path, tt.code, w.Code)
+		}
+		if loc := w.Header().Get("Location"); loc != tt.location {
+			t.Errorf("%s %s: expected Location '%s', got '%s'", tt.method, tt.path, tt.location, loc)
+		}
+	}
+
+	engine.RedirectTrailingSlash = false
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/users/", nil)
+	engine.ServeHTTP(w, req)
+	if w.Code != http.StatusNotFound {
+		t.Errorf("expected 404 with RedirectTrailingSlash disabled, got %d", w.Code)
+	}
+}
+
+func TestEngine_RedirectFixedPath(t *testing.T) {
+	engine := New()
+	engine.RedirectFixedPath = true
+	engine.GET("/users/:id", func(c *Context) { c.String(http.StatusOK, c.Param("id")) })
+	engine.GET("/docs/", func(c *Context) { c.String(http.StatusOK, "docs") })
+
+	tests := map[string]string{
+		"/users//5":         "/users/5",
+		"/users/./5":        "/users/5",
+		"/a/../users/5":     "/users/5",
+		"/docs//":           "/docs/",
+		"/users/../docs/./": "/docs/",
+	}
+	for path, location := range tests {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", path, nil)
+		engine.ServeHTTP(w, req)
+
+		if w.Code != http.StatusMovedPermanently {
+			t.Errorf("%s: expected status 301, got %d", path, w.Code)
+		}
+		if loc := w.Header().Get("Location"); loc != location {
+			t.Errorf("%s: expected Location '%s', got '%s'", path, location, loc)
+		}
+	}
+}
+
+func TestEngine_CatchAllNonCanonicalValue(t *testing.T) {
+	for _, fixed := range []bool{false, true} {
+		engine := New()
+		engine.RedirectFixedPath = fixed
+		engine.GET("/proxy/*url", func(c *Context) { c.String(http.StatusOK, c.Param("url")) })
+		engine.GET("/users/:id", func(c *Context) { c.String(http.StatusOK, c.Param("id")) })
+
+		// 通配符的值可以包含 // 和 . 片段
+		for path, value := range map[string]string{
+			"/proxy/http://x/a":   "http://x/a",
+			"/proxy/a//b/./c/../": "a//b/./c/../",
+		} {
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("GET", path, nil)
+			engine.ServeHTTP(w, req)
+			if w.Code != http.StatusOK || w.Body.String() != value {
+				t.Errorf("fixed=%v %s: expected 200 %q, got %d %q", fixed, path, value, w.Code, w.Body.String())
+			}
+		}
+
+		// 参数片段仍只匹配规范路径
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/users/./5", nil)
+		engine.ServeHTTP(w, req)
+		if expected := map[bool]int{false: http.StatusNotFound, true: http.StatusMovedPermanently}[fixed]; w.Code != expected {
+			t.Errorf("fixed=%v /users/./5: expected %d, got %d", fixed, expected, w.Code)
+		}
+	}
+}
@@ -45,9 +45,14 @@ func (e *TemplateEngine) Load(pattern string) error {
 
 // Static 静态文件服务（原Engine方法）
 func (e *Engine) Static(relativePath, root string) {
+	e.static(relativePath, root)
+}
+
+// static 注册静态文件路由, 返回路由节点和文件处理器; 目录缺失而跳过时返回 nil
+func (e *Engine) static(relativePath, root string) (*node, HandlerFunc) {
 	if !IsDebugMode() && !isDirExist(root) {
 		DebugPrint("静态目录缺失警告: %s (生产环境继续运行)", root)
-		return
+		return nil, nil
 	}
 
 	if _, err := os.Stat(root); os.IsNotExist(err) {
@@ -66,9 +71,11 @@ func (e *Engine) Static(relativePath, root string) {
 
 	handler := http.StripPrefix(relativePath, http.FileServer(http.Dir(root)))
 	urlPattern := relativePath + "/*filepath"
-	e.GET(urlPattern, func(c *Context) {
+	serve := func(c *Context) {
 		handler.ServeHTTP(c.Writer, c.Req)
-	})
+	}
+	_, n := e.handle(http.MethodGet, urlPattern, []HandlerFunc{serve})
+	return n, serve
 }
 
 // View 渲染模板
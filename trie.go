@@ -1,87 +1,346 @@
 package gooo
 
-type trie struct {
-	part     string
-	pattern  string // 完整路径模式
-	isWild   bool
-	children []*trie
+import (
+	"regexp"
+	"strings"
+)
+
+// node 基数树(压缩前缀树)节点, 每个HTTP方法一棵树.
+// 静态路径按公共前缀压缩存储在 path 中, 参数和通配符作为独立子节点,
+// 查找时直接在原始路径上逐字节匹配, 不做切分也不分配内存.
+type node struct {
+	path       string         // 静态节点为压缩后的路径片段, 参数节点为 ":name<约束>", 通配符节点为 "*name"
+	kind       int            // 节点类型
+	name       string         // 参数名
+	constraint *regexp.Regexp // 参数约束, 如 :id<int>
+	indices    string         // 静态子节点 path 的首字节, 与 children 一一对应
+	children   []*node        // 静态子节点
+	params     []*node        // 参数子节点, 带约束的排在前面
+	catchAll   *node          // 通配符子节点
+	pattern    string         // 完整路径模式, 非空表示该节点对应已注册路由
+	handlers   []HandlerFunc  // 注册时计算好的完整处理器链
 }
 
-// 匹配子节点
-func (t *trie) matchChild(part string) *trie {
-	for _, child := range t.children {
-		if child.part == part || child.isWild {
-			return child
+// 子节点匹配优先级: 静态 > 带约束参数 > 参数 > 通配符
+const (
+	kindStatic = iota
+	kindConstrained
+	kindParam
+	kindCatchAll
+)
+
+// paramConstraints 内置的参数约束类型
+var paramConstraints = map[string]string{
+	"int":   `-?[0-9]+`,
+	"uint":  `[0-9]+`,
+	"alpha": `[a-zA-Z]+`,
+	"alnum": `[a-zA-Z0-9]+`,
+	"uuid":  `[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}`,
+}
+
+// parseParam 拆分参数片段, ":id<int>" 返回 "id", "int"
+func parseParam(part string) (name, constraint string) {
+	name = part[1:]
+	if i := strings.IndexByte(name, '<'); i >= 0 && name[len(name)-1] == '>' {
+		return name[:i], name[i+1 : len(name)-1]
+	}
+	return name, ""
+}
+
+// compileConstraint 编译参数约束, 内置类型名优先, 否则按正则表达式处理
+func compileConstraint(part string) *regexp.Regexp {
+	_, constraint := parseParam(part)
+	if constraint == "" {
+		return nil
+	}
+	if expr, ok := paramConstraints[constraint]; ok {
+		constraint = expr
+	}
+	re, err := regexp.Compile("^(?:" + constraint + ")$")
+	if err != nil {
+		panic("无效的参数约束: " + part + ": " + err.Error())
+	}
+	return re
+}
+
+// partKind 返回路径片段类型
+func partKind(part string) int {
+	switch {
+	case part != "" && part[0] == ':':
+		if _, constraint := parseParam(part); constraint != "" {
+			return kindConstrained
 		}
+		return kindParam
+	case part != "" && part[0] == '*':
+		return kindCatchAll
+	default:
+		return kindStatic
 	}
-	return nil
 }
 
-// 匹配所有子节点
-// 保留此方法用于未来可能的扩展功能，如：
-// - 多匹配路由
-// - 路由回溯
-// - 更复杂的通配符处理
-// func (t *trie) matchChildren(part string) []*trie {
-// 	nodes := make([]*trie, 0)
-// 	for _, child := range t.children {
-// 		if child.part == part || child.isWild {
-// 			nodes = append(nodes, child)
-// 		}
-// 	}
-// 	return nodes
-// }
-
-// 插入路径到前缀树
-func (t *trie) insert(pattern string, parts []string) {
-	node := t
-	for i, part := range parts {
-		child := node.matchChild(part)
-		if child == nil {
+// sameConstraint 判断两个参数片段的约束是否相同
+func sameConstraint(a, b string) bool {
+	_, ca := parseParam(a)
+	_, cb := parseParam(b)
+	return ca == cb
+}
+
+// countParams 统计模式中的参数和通配符数量
+func countParams(pattern string) int {
+	return strings.Count(pattern, "/:") + strings.Count(pattern, "/*")
+}
+
+// nextToken 返回模式开头的片段: 参数/通配符到下一个 / 为止, 静态片段到下一个参数或通配符为止
+func nextToken(pattern string) string {
+	switch pattern[0] {
+	case ':', '*':
+		if end := strings.IndexByte(pattern, '/'); end >= 0 {
+			return pattern[:end]
+		}
+		return pattern
+	}
+	for i := 1; i < len(pattern); i++ {
+		if (pattern[i] == ':' || pattern[i] == '*') && pattern[i-1] == '/' {
+			return pattern[:i]
+		}
+	}
+	return pattern
+}
+
+// insert 插入路由模式, 返回模式对应的节点
+func (n *node) insert(pattern string) *node {
+	cur := n
+	rest := pattern
+	for rest != "" {
+		token := nextToken(rest)
+		switch token[0] {
+		case ':':
+			cur = cur.paramChild(token)
+		case '*':
 			// 验证通配符节点只能出现在末尾
-			if part[0] == '*' && i != len(parts)-1 {
+			if len(token) != len(rest) {
 				panic("通配符路由必须位于路径末尾")
 			}
-			child = &trie{
-				part:     part,
-				isWild:   part[0] == ':' || part[0] == '*',
-				children: make([]*trie, 0),
-			}
-			node.children = append(node.children, child)
-		} else {
-			// 检查路由冲突
-			if child.part != part && (!child.isWild && !(part[0] == ':' || part[0] == '*')) {
-				panic("路由冲突: " + pattern + " 与 " + child.pattern)
-			}
+			cur = cur.catchAllChild(token)
+		default:
+			var consumed int
+			cur, consumed = cur.staticChild(token)
+			token = token[:consumed]
 		}
-		node = child
+		rest = rest[len(token):]
 	}
 	// 检查是否重复注册相同路径
-	if node.pattern != "" {
+	if cur.pattern != "" {
 		panic("重复注册路由: " + pattern)
 	}
-	node.pattern = pattern
+	cur.pattern = pattern
+	return cur
+}
+
+// staticChild 沿静态片段向下一层, 必要时拆分已有节点, 返回子节点和消耗的字节数
+func (n *node) staticChild(literal string) (*node, int) {
+	for i := 0; i < len(n.indices); i++ {
+		if n.indices[i] != literal[0] {
+			continue
+		}
+		child := n.children[i]
+		l := commonPrefix(child.path, literal)
+		if l < len(child.path) {
+			// 公共前缀作为新的父节点, 原节点保留剩余部分及其子节点
+			split := &node{
+				path:     child.path[:l],
+				kind:     kindStatic,
+				indices:  child.path[l : l+1],
+				children: []*node{child},
+			}
+			child.path = child.path[l:]
+			n.children[i] = split
+			child = split
+		}
+		return child, l
+	}
+	child := &node{path: literal, kind: kindStatic}
+	n.indices += literal[:1]
+	n.children = append(n.children, child)
+	return child, len(literal)
+}
+
+// paramChild 返回与参数片段完全相同的子节点, 不存在时创建
+func (n *node) paramChild(token string) *node {
+	for _, child := range n.params {
+		if child.path == token {
+			return child
+		}
+	}
+	name, _ := parseParam(token)
+	child := &node{
+		path:       token,
+		kind:       partKind(token),
+		name:       name,
+		constraint: compileConstraint(token),
+	}
+	// 保持带约束参数在前
+	i := len(n.params)
+	if child.kind == kindConstrained {
+		i = 0
+		for i < len(n.params) && n.params[i].kind == kindConstrained {
+			i++
+		}
+	}
+	n.params = append(n.params, nil)
+	copy(n.params[i+1:], n.params[i:])
+	n.params[i] = child
+	return child
+}
+
+// catchAllChild 返回通配符子节点, 不存在时创建
+func (n *node) catchAllChild(token string) *node {
+	if n.catchAll != nil {
+		if n.catchAll.path != token {
+			panic("路由冲突: " + token + " 与 " + n.catchAll.pattern)
+		}
+		return n.catchAll
+	}
+	n.catchAll = &node{path: token, kind: kindCatchAll, name: token[1:]}
+	return n.catchAll
 }
 
-// 搜索匹配的路由节点
-func (t *trie) search(parts []string) *trie {
-	node := t
-	for _, part := range parts {
-		child := node.matchChild(part)
-		if child == nil {
-			return nil
+// search 查找与路径匹配的路由节点, 参数追加到 params (可为 nil).
+// 按 静态 > 带约束参数 > 参数 > 通配符 的优先级匹配, 子树匹配失败时回溯尝试下一个候选节点,
+// 因此结果与注册顺序无关
+func (n *node) search(path string, params *Params) *node {
+	if path == "" {
+		// 只有完整匹配的节点才返回
+		if n.pattern != "" {
+			return n
 		}
-		node = child
+		// 通配符可匹配空的剩余路径, 如 /static/*filepath 匹配 /static/
+		if n.catchAll != nil && n.catchAll.pattern != "" {
+			appendParam(params, n.catchAll.name, "")
+			return n.catchAll
+		}
+		return nil
+	}
+
+	// 1. 静态子节点
+	for i := 0; i < len(n.indices); i++ {
+		if n.indices[i] != path[0] {
+			continue
+		}
+		child := n.children[i]
+		if strings.HasPrefix(path, child.path) {
+			if found := child.search(path[len(child.path):], params); found != nil {
+				return found
+			}
+		} else if len(child.path) == len(path)+1 && strings.HasPrefix(child.path, path) && child.path[len(path)] == '/' &&
+			child.pattern == "" && child.catchAll != nil && child.catchAll.pattern != "" {
+			// /static 匹配 /static/*filepath
+			appendParam(params, child.catchAll.name, "")
+			return child.catchAll
+		}
+		break
+	}
 
-		// 遇到通配符节点时提前返回
-		if node.part[0] == '*' {
-			return node
+	// 2. 参数子节点, 匹配到下一个 / 为止的非空片段; . 和 .. 不是合法的参数值, 交由 RedirectFixedPath 处理
+	if len(n.params) > 0 && path[0] != '/' {
+		end := strings.IndexByte(path, '/')
+		if end < 0 {
+			end = len(path)
 		}
+		if segment := path[:end]; segment != "." && segment != ".." {
+			for _, child := range n.params {
+				if child.constraint != nil && !child.constraint.MatchString(segment) {
+					continue
+				}
+				mark := appendParam(params, child.name, segment)
+				if found := child.search(path[end:], params); found != nil {
+					return found
+				}
+				truncateParams(params, mark)
+			}
+		}
+	}
+
+	// 3. 通配符子节点, 匹配剩余全部路径
+	if n.catchAll != nil && n.catchAll.pattern != "" && path[0] != '/' {
+		appendParam(params, n.catchAll.name, path)
+		return n.catchAll
+	}
+	return nil
+}
+
+// appendParam 追加参数, 返回追加前的长度以便回溯
+func appendParam(params *Params, key, value string) int {
+	if params == nil {
+		return 0
+	}
+	mark := len(*params)
+	*params = append(*params, Param{Key: key, Value: value})
+	return mark
+}
+
+// truncateParams 回溯时丢弃失败分支追加的参数
+func truncateParams(params *Params, mark int) {
+	if params != nil {
+		*params = (*params)[:mark]
 	}
+}
 
-	// 只有完整匹配的节点才返回
-	if node.pattern == "" {
+// conflict 查找与给定模式结构相同(仅参数名不同)的已注册节点, 约束不同的参数不视为冲突
+func (n *node) conflict(pattern string) *node {
+	if pattern == "" {
+		if n.pattern != "" {
+			return n
+		}
 		return nil
 	}
-	return node
+
+	token := nextToken(pattern)
+	switch token[0] {
+	case ':':
+		for _, child := range n.params {
+			if !sameConstraint(child.path, token) {
+				continue
+			}
+			if found := child.conflict(pattern[len(token):]); found != nil {
+				return found
+			}
+		}
+	case '*':
+		if n.catchAll != nil && n.catchAll.pattern != "" {
+			return n.catchAll
+		}
+	default:
+		for i := 0; i < len(n.indices); i++ {
+			if n.indices[i] == pattern[0] && strings.HasPrefix(pattern, n.children[i].path) {
+				return n.children[i].conflict(pattern[len(n.children[i].path):])
+			}
+		}
+	}
+	return nil
+}
+
+// walk 深度优先遍历所有已注册路由节点
+func (n *node) walk(fn func(n *node)) {
+	if n.pattern != "" {
+		fn(n)
+	}
+	for _, child := range n.children {
+		child.walk(fn)
+	}
+	for _, child := range n.params {
+		child.walk(fn)
+	}
+	if n.catchAll != nil {
+		n.catchAll.walk(fn)
+	}
+}
+
+// commonPrefix 返回两个字符串公共前缀的长度
+func commonPrefix(a, b string) int {
+	i := 0
+	for i < len(a) && i < len(b) && a[i] == b[i] {
+		i++
+	}
+	return i
 }
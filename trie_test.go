@@ -4,55 +4,87 @@ import (
 	"testing"
 )
 
-func TestTrieInsertAndSearch(t *testing.T) {
-	root := &trie{
-		part:     "/",
-		children: make([]*trie, 0),
+// mustSearch 查找路径并校验匹配的路由模式
+func mustSearch(t *testing.T, root *node, path, pattern string) Params {
+	t.Helper()
+	params := make(Params, 0)
+	n := root.search(path, &params)
+	if n == nil {
+		t.Fatalf("%s: expected %s, got no match", path, pattern)
 	}
+	if n.pattern != pattern {
+		t.Fatalf("%s: expected %s, got %s", path, pattern, n.pattern)
+	}
+	return params
+}
+
+func TestTreeInsertAndSearch(t *testing.T) {
+	root := &node{}
 
 	// 测试基本路由
-	root.insert("/hello", parsePattern("/hello"))
-	if node := root.search(parsePattern("/hello")); node == nil || node.pattern != "/hello" {
-		t.Fatalf("basic route search failed")
-	}
+	root.insert("/hello")
+	mustSearch(t, root, "/hello", "/hello")
 
 	// 测试不存在的路由
-	if node := root.search(parsePattern("/notfound")); node != nil {
+	if n := root.search("/notfound", nil); n != nil {
 		t.Fatalf("should not find non-existent route")
 	}
+	if n := root.search("/hell", nil); n != nil {
+		t.Fatalf("should not match a prefix of a static route")
+	}
 }
 
-func TestTrieDynamicParam(t *testing.T) {
-	root := &trie{}
+func TestTreeDynamicParam(t *testing.T) {
+	root := &node{}
 
 	// 测试动态参数路由
-	root.insert("/user/:id", parsePattern("/user/:id"))
-	node := root.search(parsePattern("/user/123"))
-	if node == nil || node.pattern != "/user/:id" {
-		t.Fatalf("dynamic param route search failed")
+	root.insert("/user/:id")
+	params := mustSearch(t, root, "/user/123", "/user/:id")
+	if params.ByName("id") != "123" {
+		t.Fatalf("expected id 123, got %v", params)
 	}
 }
 
-func TestTrieWildcard(t *testing.T) {
-	root := &trie{}
+func TestTreeWildcard(t *testing.T) {
+	root := &node{}
 
 	// 测试通配符路由
-	root.insert("/static/*filepath", parsePattern("/static/*filepath"))
-	node := root.search(parsePattern("/static/css/style.css"))
-	if node == nil || node.pattern != "/static/*filepath" {
-		t.Fatalf("wildcard route search failed")
+	root.insert("/static/*filepath")
+	params := mustSearch(t, root, "/static/css/style.css", "/static/*filepath")
+	if params.ByName("filepath") != "css/style.css" {
+		t.Fatalf("expected filepath css/style.css, got %v", params)
 	}
+
+	// 通配符可匹配空的剩余路径
+	mustSearch(t, root, "/static/", "/static/*filepath")
+	mustSearch(t, root, "/static", "/static/*filepath")
 }
 
-func TestTrieConflict(t *testing.T) {
-	root := &trie{
-		part:     "/",
-		children: make([]*trie, 0),
+func TestTreeCompression(t *testing.T) {
+	root := &node{}
+	for _, pattern := range []string{"/search", "/support", "/src/:file", "/s"} {
+		root.insert(pattern)
+	}
+
+	// 公共前缀 /s 被拆分为独立节点
+	if len(root.children) != 1 || root.children[0].path != "/s" {
+		t.Fatalf("expected compressed /s node, got %+v", root.children)
+	}
+	for _, pattern := range []string{"/search", "/support", "/s"} {
+		mustSearch(t, root, pattern, pattern)
 	}
+	mustSearch(t, root, "/src/main.go", "/src/:file")
+	if n := root.search("/se", nil); n != nil {
+		t.Fatalf("should not match inner node /se")
+	}
+}
+
+func TestTreeConflict(t *testing.T) {
+	root := &node{}
 
 	// 测试路由共存 - 允许具体路径和参数路径共存
-	root.insert("/user/name", parsePattern("/user/name"))
-	root.insert("/user/:id", parsePattern("/user/:id")) // 这应该不会触发panic
+	root.insert("/user/name")
+	root.insert("/user/:id") // 这应该不会触发panic
 
 	// 测试真正的冲突场景 - 相同路径不同处理
 	defer func() {
@@ -60,6 +92,185 @@ func TestTrieConflict(t *testing.T) {
 			t.Fatalf("should panic on real route conflict")
 		}
 	}()
-	root.insert("/user/name", parsePattern("/user/name"))
-	root.insert("/user/name", parsePattern("/user/name")) // 这会触发panic
+	root.insert("/user/name") // 这会触发panic
+}
+
+func TestTreePriority(t *testing.T) {
+	root := &node{}
+
+	// 参数路由先于静态路由注册
+	root.insert("/users/:id")
+	root.insert("/users/*rest")
+	root.insert("/users/me")
+
+	tests := map[string]string{
+		"/users/me":      "/users/me",
+		"/users/42":      "/users/:id",
+		"/users/42/tags": "/users/*rest",
+	}
+	for path, pattern := range tests {
+		mustSearch(t, root, path, pattern)
+	}
+}
+
+func TestTreeBacktracking(t *testing.T) {
+	root := &node{}
+	root.insert("/users/me")
+	root.insert("/users/:id/posts")
+	root.insert("/:a/:b")
+	root.insert("/:x/o/:y")
+
+	tests := map[string]string{
+		// 静态分支 me 没有 posts 子节点, 回溯到参数分支
+		"/users/me/posts": "/users/:id/posts",
+		"/users/me":       "/users/me",
+		"/foo/bar":        "/:a/:b",
+		"/foo/o/bar":      "/:x/o/:y",
+	}
+	for path, pattern := range tests {
+		mustSearch(t, root, path, pattern)
+	}
+
+	// 回溯后参数只保留最终分支的值
+	params := mustSearch(t, root, "/users/me/posts", "/users/:id/posts")
+	if len(params) != 1 || params.ByName("id") != "me" {
+		t.Fatalf("unexpected params after backtracking: %v", params)
+	}
+
+	if n := root.search("/users/me/comments", nil); n != nil {
+		t.Errorf("expected no match, got %s", n.pattern)
+	}
+}
+
+func TestTreeParamNameConflict(t *testing.T) {
+	root := &node{}
+	root.insert("/user/:id")
+
+	if n := root.conflict("/user/:name"); n == nil || n.pattern != "/user/:id" {
+		t.Fatalf("expected conflict with /user/:id")
+	}
+	if n := root.conflict("/user/me"); n != nil {
+		t.Fatalf("static route should not conflict with param route")
+	}
+}
+
+func TestTreeConstraints(t *testing.T) {
+	root := &node{}
+	root.insert("/orders/:id<int>")
+	root.insert("/orders/:slug<[a-z-]+>")
+	root.insert("/orders/:any")
+	root.insert("/keys/:uuid<uuid>")
+
+	tests := map[string]string{
+		"/orders/42":        "/orders/:id<int>",
+		"/orders/new-order": "/orders/:slug<[a-z-]+>",
+		"/orders/ABC_1":     "/orders/:any",
+		"/keys/123e4567-e89b-12d3-a456-426614174000": "/keys/:uuid<uuid>",
+	}
+	for path, pattern := range tests {
+		mustSearch(t, root, path, pattern)
+	}
+
+	if n := root.search("/keys/not-a-uuid", nil); n != nil {
+		t.Errorf("expected no match, got %s", n.pattern)
+	}
+}
+
+func TestTreeInvalidConstraint(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Fatalf("invalid constraint should panic")
+		}
+	}()
+	root := &node{}
+	root.insert("/bad/:id<[a-z>")
+}
+
+func TestTreeWildcardNotLast(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Fatalf("wildcard in the middle should panic")
+		}
+	}()
+	root := &node{}
+	root.insert("/files/*path/edit")
+}
+
+func TestTreeZeroAlloc(t *testing.T) {
+	root := benchmarkTree()
+	params := make(Params, 0, 4)
+	for _, path := range []string{"/api/v1/users", "/api/v1/users/42/posts/7"} {
+		allocs := testing.AllocsPerRun(100, func() {
+			params = params[:0]
+			root.search(path, &params)
+		})
+		if allocs != 0 {
+			t.Errorf("%s: expected 0 allocs, got %v", path, allocs)
+		}
+	}
+}
+
+// benchmarkTree 构造基准测试用的路由树
+func benchmarkTree() *node {
+	root := &node{}
+	for _, pattern := range []string{
+		"/",
+		"/about",
+		"/api/v1/users",
+		"/api/v1/users/:id",
+		"/api/v1/users/:id/posts",
+		"/api/v1/users/:id/posts/:post",
+		"/api/v1/orders/:id<int>",
+		"/api/v1/status",
+		"/static/*filepath",
+	} {
+		root.insert(pattern)
+	}
+	return root
+}
+
+func BenchmarkTreeStatic(b *testing.B) {
+	root := benchmarkTree()
+	params := make(Params, 0, 4)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		params = params[:0]
+		root.search("/api/v1/status", &params)
+	}
+}
+
+func BenchmarkTreeParam(b *testing.B) {
+	root := benchmarkTree()
+	params := make(Params, 0, 4)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		params = params[:0]
+		root.search("/api/v1/users/42/posts/7", &params)
+	}
+}
+
+func BenchmarkTreeCatchAll(b *testing.B) {
+	root := benchmarkTree()
+	params := make(Params, 0, 4)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		params = params[:0]
+		root.search("/static/css/site/main.css", &params)
+	}
+}
+
+func BenchmarkRouterStatic(b *testing.B) {
+	r := newRouter()
+	r.addRoute("GET", "/api/v1/status", []HandlerFunc{func(c *Context) {}})
+	r.addRoute("GET", "/api/v1/users/:id", []HandlerFunc{func(c *Context) {}})
+	params := make(Params, 0, r.maxParams)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		params = params[:0]
+		r.getRoute("GET", "/api/v1/status", &params)
+	}
 }
@@ -0,0 +1,190 @@
+package gooo
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode"
+)
+
+// 上传文件校验错误
+var (
+	ErrFileTooLarge       = errors.New("uploaded file too large")
+	ErrFileTypeNotAllowed = errors.New("uploaded file type not allowed")
+)
+
+// maxMultipartMemory 返回解析 multipart 表单时保存在内存中的最大字节数
+func (c *Context) maxMultipartMemory() int64 {
+	if c.engine != nil && c.engine.MaxMultipartMemory > 0 {
+		return c.engine.MaxMultipartMemory
+	}
+	return defaultMultipartMemory
+}
+
+// requestBodyLimit 返回请求体的最大字节数, 0 表示不限制
+func (c *Context) requestBodyLimit() int64 {
+	if c.engine == nil {
+		return 0
+	}
+	if c.engine.MaxRequestBodySize > 0 {
+		return c.engine.MaxRequestBodySize
+	}
+	if c.engine.MaxUploadFileSize > 0 && c.requestContentType() == MIMEMultipartPOSTForm {
+		return c.engine.MaxUploadFileSize + c.maxMultipartMemory()
+	}
+	return 0
+}
+
+// limitRequestBody 在首次读取请求体前按 requestBodyLimit 包装 Req.Body,
+// 超出限制时读取返回 *http.MaxBytesError, 不会把整个请求体读入内存或临时文件
+func (c *Context) limitRequestBody() {
+	if c.bodyLimited || c.Req == nil || c.Req.Body == nil || c.Req.Body == http.NoBody {
+		return
+	}
+	c.bodyLimited = true
+	if limit := c.requestBodyLimit(); limit > 0 {
+		c.Req.Body = http.MaxBytesReader(c.Writer, c.Req.Body, limit)
+	}
+}
+
+// MultipartForm 解析并返回 multipart 表单, 超出 Engine.MaxMultipartMemory 的部分写入临时文件,
+// 请求体超出 Engine.MaxRequestBodySize 时返回 *http.MaxBytesError
+func (c *Context) MultipartForm() (*multipart.Form, error) {
+	c.limitRequestBody()
+	if err := c.Req.ParseMultipartForm(c.maxMultipartMemory()); err != nil {
+		return nil, err
+	}
+	return c.Req.MultipartForm, nil
+}
+
+// FormFile 返回指定字段的第一个上传文件, 并按 Engine 的 MaxUploadFileSize 和 AllowedUploadTypes 校验
+func (c *Context) FormFile(name string) (*multipart.FileHeader, error) {
+	form, err := c.MultipartForm()
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			return nil, fmt.Errorf("%w: request body exceeds %d bytes", ErrFileTooLarge, tooLarge.Limit)
+		}
+		return nil, err
+	}
+	files := form.File[name]
+	if len(files) == 0 {
+		return nil, http.ErrMissingFile
+	}
+	var maxSize int64
+	var allowed []string
+	if c.engine != nil {
+		maxSize, allowed = c.engine.MaxUploadFileSize, c.engine.AllowedUploadTypes
+	}
+	if err := CheckUploadedFile(files[0], maxSize, allowed...); err != nil {
+		return nil, err
+	}
+	return files[0], nil
+}
+
+// CheckUploadedFile 校验上传文件的大小和类型. maxSize 为0表示不限制大小;
+// allowed 为空表示不限制类型, 否则文件类型必须匹配其中之一(支持 image/* 形式).
+// 文件类型根据内容嗅探, 不信任客户端提交的 Content-Type.
+func CheckUploadedFile(file *multipart.FileHeader, maxSize int64, allowed ...string) error {
+	if maxSize > 0 && file.Size > maxSize {
+		return fmt.Errorf("%w: %s is %d bytes, limit is %d", ErrFileTooLarge, file.Filename, file.Size, maxSize)
+	}
+	if len(allowed) == 0 {
+		return nil
+	}
+	contentType, err := detectFileType(file)
+	if err != nil {
+		return err
+	}
+	for _, pattern := range allowed {
+		if matchMIME(pattern, contentType) {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: %s is %s", ErrFileTypeNotAllowed, file.Filename, contentType)
+}
+
+// detectFileType 读取文件开头的512字节嗅探 MIME 类型(不含参数)
+func detectFileType(file *multipart.FileHeader) (string, error) {
+	f, err := file.Open()
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	buf := make([]byte, 512)
+	n, err := io.ReadFull(f, buf)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) && !errors.Is(err, io.EOF) {
+		return "", err
+	}
+	contentType, _, _ := mime.ParseMediaType(http.DetectContentType(buf[:n]))
+	return contentType, nil
+}
+
+// matchMIME 判断类型是否匹配 pattern, pattern 可为 type/* 或 */*
+func matchMIME(pattern, contentType string) bool {
+	pattern = strings.ToLower(strings.TrimSpace(pattern))
+	if pattern == "*/*" || pattern == contentType {
+		return true
+	}
+	if prefix, ok := strings.CutSuffix(pattern, "/*"); ok {
+		return strings.HasPrefix(contentType, prefix+"/")
+	}
+	return false
+}
+
+// SanitizeFilename 清理客户端提交的文件名: 去掉目录部分和控制字符, 拒绝 . 和 .. ,
+// 开头的点会被去掉以免生成隐藏文件. 清理后为空时返回 "file".
+func SanitizeFilename(name string) string {
+	// 客户端可能使用 Windows 路径分隔符
+	name = strings.ReplaceAll(name, "\\", "/")
+	if i := strings.LastIndexByte(name, '/'); i >= 0 {
+		name = name[i+1:]
+	}
+	name = strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) || r == ':' {
+			return -1
+		}
+		return r
+	}, name)
+	name = strings.TrimLeft(strings.TrimSpace(name), ".")
+	if name == "" {
+		return "file"
+	}
+	return name
+}
+
+// SaveUploadedFile 将上传文件保存到 dir 目录下, 文件名经 SanitizeFilename 清理, 返回保存路径.
+// 目录不存在时自动创建, 同名文件会被覆盖.
+func (c *Context) SaveUploadedFile(file *multipart.FileHeader, dir string) (string, error) {
+	dst := filepath.Join(dir, SanitizeFilename(file.Filename))
+	// 防御性检查: 保存路径必须位于 dir 之内
+	if rel, err := filepath.Rel(dir, dst); err != nil || strings.HasPrefix(rel, "..") || filepath.IsAbs(rel) {
+		return "", fmt.Errorf("invalid upload filename %q", file.Filename)
+	}
+
+	src, err := file.Open()
+	if err != nil {
+		return "", err
+	}
+	defer src.Close()
+
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return "", err
+	}
+	out, err := os.OpenFile(dst, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o640)
+	if err != nil {
+		return "", err
+	}
+	if _, err := io.Copy(out, src); err != nil {
+		out.Close()
+		return "", err
+	}
+	return dst, out.Close()
+}
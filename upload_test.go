@@ -0,0 +1,207 @@
+package gooo
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// pngHeader PNG 文件签名, 用于类型嗅探
+var pngHeader = []byte("\x89PNG\r\n\x1a\n0000000000")
+
+// newUploadRequest 构造包含单个文件的 multipart 请求
+func newUploadRequest(t *testing.T, field, filename string, content []byte) *http.Request {
+	t.Helper()
+	var buf bytes.Buffer
+	mw := multipart.NewWriter(&buf)
+	fw, err := mw.CreateFormFile(field, filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fw.Write(content)
+	mw.WriteField("title", "avatar")
+	mw.Close()
+	req := httptest.NewRequest("POST", "/upload", &buf)
+	req.Header.Set("Content-Type", mw.FormDataContentType())
+	return req
+}
+
+func TestSanitizeFilename(t *testing.T) {
+	tests := map[string]string{
+		"photo.png":             "photo.png",
+		"../../etc/passwd":      "passwd",
+		`..\..\windows\win.ini`: "win.ini",
+		"/abs/path/a.txt":       "a.txt",
+		"..":                    "file",
+		".":                     "file",
+		".hidden":               "hidden",
+		"a\x00b\nc.txt":         "abc.txt",
+		"C:evil.exe":            "Cevil.exe",
+		"":                      "file",
+	}
+	for input, expected := range tests {
+		if got := SanitizeFilename(input); got != expected {
+			t.Errorf("SanitizeFilename(%q) = %q, want %q", input, got, expected)
+		}
+	}
+}
+
+func TestFormFileAndSave(t *testing.T) {
+	dir := t.TempDir()
+	engine := New()
+	engine.POST("/upload", func(c *Context) {
+		file, err := c.FormFile("avatar")
+		if err != nil {
+			c.String(http.StatusBadRequest, err.Error())
+			return
+		}
+		form, _ := c.MultipartForm()
+		dst, err := c.SaveUploadedFile(file, dir)
+		if err != nil {
+			c.String(http.StatusInternalServerError, err.Error())
+			return
+		}
+		c.String(http.StatusOK, "%s %s", form.Value["title"][0], filepath.Base(dst))
+	})
+
+	w := httptest.NewRecorder()
+	engine.ServeHTTP(w, newUploadRequest(t, "avatar", "../../me.png", pngHeader))
+	if w.Code != http.StatusOK || w.Body.String() != "avatar me.png" {
+		t.Fatalf("Unexpected response %d %s", w.Code, w.Body.String())
+	}
+	data, err := os.ReadFile(filepath.Join(dir, "me.png"))
+	if err != nil || !bytes.Equal(data, pngHeader) {
+		t.Fatalf("File not saved in upload dir: %v", err)
+	}
+
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newUploadRequest(t, "other", "me.png", pngHeader))
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected 400 for missing file, got %d", w.Code)
+	}
+}
+
+func TestFormFileLimits(t *testing.T) {
+	engine := New()
+	engine.MaxMultipartMemory = 1 << 10
+	engine.MaxUploadFileSize = 64
+	engine.AllowedUploadTypes = []string{"image/*"}
+
+	var uploadErr error
+	engine.POST("/upload", func(c *Context) {
+		_, uploadErr = c.FormFile("avatar")
+	})
+
+	engine.ServeHTTP(httptest.NewRecorder(), newUploadRequest(t, "avatar", "a.png", pngHeader))
+	if uploadErr != nil {
+		t.Errorf("Expected png to be accepted, got %v", uploadErr)
+	}
+
+	engine.ServeHTTP(httptest.NewRecorder(), newUploadRequest(t, "avatar", "a.png", bytes.Repeat([]byte("x"), 100)))
+	if !errors.Is(uploadErr, ErrFileTooLarge) {
+		t.Errorf("Expected ErrFileTooLarge, got %v", uploadErr)
+	}
+
+	// 扩展名和 Content-Type 不可信, 以内容嗅探为准
+	engine.ServeHTTP(httptest.NewRecorder(), newUploadRequest(t, "avatar", "a.png", []byte("<html><script>")))
+	if !errors.Is(uploadErr, ErrFileTypeNotAllowed) {
+		t.Errorf("Expected ErrFileTypeNotAllowed, got %v", uploadErr)
+	}
+}
+
+func TestMatchMIME(t *testing.T) {
+	tests := []struct {
+		pattern, contentType string
+		expected             bool
+	}{
+		{"image/png", "image/png", true},
+		{"image/*", "image/jpeg", true},
+		{"*/*", "text/plain", true},
+		{"image/*", "text/plain", false},
+		{"IMAGE/PNG", "image/png", true},
+		{"image/png", "image/pngx", false},
+	}
+	for _, tt := range tests {
+		if got := matchMIME(tt.pattern, tt.contentType); got != tt.expected {
+			t.Errorf("matchMIME(%q, %q) = %v, want %v", tt.pattern, tt.contentType, got, tt.expected)
+		}
+	}
+}
+
+// countingReader 统计从请求体读取的字节数
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (r *countingReader) Read(p []byte) (int, error) {
+	n, err := r.r.Read(p)
+	r.n += int64(n)
+	return n, err
+}
+
+func TestFormFileBodyLimit(t *testing.T) {
+	engine := New()
+	engine.MaxMultipartMemory = 1 << 10
+	engine.MaxUploadFileSize = 64
+
+	var uploadErr error
+	engine.POST("/upload", func(c *Context) {
+		_, uploadErr = c.FormFile("avatar")
+	})
+
+	// 请求体超出 MaxUploadFileSize + MaxMultipartMemory 时停止读取, 不会读完整个请求体
+	req := newUploadRequest(t, "avatar", "a.png", bytes.Repeat([]byte("x"), 1<<20))
+	body := &countingReader{r: req.Body}
+	req.Body = io.NopCloser(body)
+	engine.ServeHTTP(httptest.NewRecorder(), req)
+	if !errors.Is(uploadErr, ErrFileTooLarge) {
+		t.Errorf("Expected ErrFileTooLarge, got %v", uploadErr)
+	}
+	if body.n > 64<<10 {
+		t.Errorf("Expected body reading to stop early, read %d bytes", body.n)
+	}
+}
+
+func TestBindBodyLimit(t *testing.T) {
+	engine := New()
+	engine.MaxRequestBodySize = 32
+	engine.POST("/upload", func(c *Context) {
+		var form struct {
+			Title string `form:"title"`
+		}
+		if err := c.Bind(&form); err != nil {
+			return
+		}
+		c.String(http.StatusOK, form.Title)
+	})
+
+	w := httptest.NewRecorder()
+	engine.ServeHTTP(w, newUploadRequest(t, "avatar", "a.png", bytes.Repeat([]byte("x"), 1<<10)))
+	if w.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("Expected status 413 for multipart body, got %d", w.Code)
+	}
+
+	w = httptest.NewRecorder()
+	req := httptest.NewRequest("POST", "/upload", strings.NewReader("title="+strings.Repeat("x", 64)))
+	req.Header.Set("Content-Type", MIMEPOSTForm)
+	engine.ServeHTTP(w, req)
+	if w.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("Expected status 413 for urlencoded body, got %d", w.Code)
+	}
+
+	w = httptest.NewRecorder()
+	req = httptest.NewRequest("POST", "/upload", strings.NewReader("title=ok"))
+	req.Header.Set("Content-Type", MIMEPOSTForm)
+	engine.ServeHTTP(w, req)
+	if w.Code != http.StatusOK || w.Body.String() != "ok" {
+		t.Errorf("Expected small body to bind, got %d %q", w.Code, w.Body.String())
+	}
+}
@@ -0,0 +1,73 @@
+package gooo
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// URL 根据路由名称生成路径, params 为 参数名/参数值 交替出现的列表
+//
+//	engine.GET("/users/:id<int>/files/*path", h).Name("user.file")
+//	engine.URL("user.file", "id", 5, "path", "a/b.txt") // "/users/5/files/a/b.txt"
+func (engine *Engine) URL(name string, params ...any) (string, error) {
+	route, ok := engine.namedRoutes[name]
+	if !ok {
+		return "", fmt.Errorf("url: route %q not found", name)
+	}
+	if len(params)%2 != 0 {
+		return "", fmt.Errorf("url: route %q: odd number of params", name)
+	}
+
+	values := make(map[string]string, len(params)/2)
+	for i := 0; i < len(params); i += 2 {
+		key, ok := params[i].(string)
+		if !ok {
+			return "", fmt.Errorf("url: route %q: param name %v is not a string", name, params[i])
+		}
+		values[key] = fmt.Sprint(params[i+1])
+	}
+
+	var sb strings.Builder
+	parts := parsePattern(route.Path)
+	used := 0
+	for _, part := range parts {
+		sb.WriteByte('/')
+		switch partKind(part) {
+		case kindStatic:
+			sb.WriteString(part)
+			continue
+		case kindCatchAll:
+			value, ok := values[part[1:]]
+			if !ok {
+				return "", fmt.Errorf("url: route %q: missing param %q", name, part[1:])
+			}
+			used++
+			segments := strings.Split(strings.TrimPrefix(value, "/"), "/")
+			for i, seg := range segments {
+				segments[i] = url.PathEscape(seg)
+			}
+			sb.WriteString(strings.Join(segments, "/"))
+			continue
+		}
+
+		key, _ := parseParam(part)
+		value, ok := values[key]
+		if !ok {
+			return "", fmt.Errorf("url: route %q: missing param %q", name, key)
+		}
+		if re := compileConstraint(part); re != nil && !re.MatchString(value) {
+			return "", fmt.Errorf("url: route %q: param %q value %q does not satisfy %s", name, key, value, part)
+		}
+		used++
+		sb.WriteString(url.PathEscape(value))
+	}
+	if used != len(values) {
+		return "", fmt.Errorf("url: route %q: unknown params in %v", name, params)
+	}
+
+	if sb.Len() == 0 || (strings.HasSuffix(route.Path, "/") && partKind(parts[len(parts)-1]) != kindCatchAll) {
+		sb.WriteByte('/')
+	}
+	return sb.String(), nil
+}
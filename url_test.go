@@ -0,0 +1,92 @@
+package gooo
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestEngine_URL(t *testing.T) {
+	engine := New()
+	api := engine.Group("/api/v1")
+	api.GET("/users/:id<int>", func(c *Context) {}).Name("user")
+	api.GET("/files/*filepath", func(c *Context) {}).Name("file")
+	engine.POST("/dirs/", func(c *Context) {}).Name("dirs")
+	engine.GET("/", func(c *Context) {}).Name("home")
+
+	tests := []struct {
+		name     string
+		params   []any
+		expected string
+	}{
+		{"user", []any{"id", 5}, "/api/v1/users/5"},
+		{"file", []any{"filepath", "css/a b.css"}, "/api/v1/files/css/a%20b.css"},
+		{"dirs", nil, "/dirs/"},
+		{"home", nil, "/"},
+	}
+	for _, tt := range tests {
+		got, err := engine.URL(tt.name, tt.params...)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", tt.name, err)
+			continue
+		}
+		if got != tt.expected {
+			t.Errorf("%s: expected %s, got %s", tt.name, tt.expected, got)
+		}
+	}
+
+	errCases := map[string][]any{
+		"user":    {"id", "abc"},
+		"file":    {},
+		"missing": {},
+	}
+	for name, params := range errCases {
+		if _, err := engine.URL(name, params...); err == nil {
+			t.Errorf("%s %v: expected error", name, params)
+		}
+	}
+	if _, err := engine.URL("user", "id"); err == nil {
+		t.Error("odd params should fail")
+	}
+	if _, err := engine.URL("user", "id", 1, "extra", 2); err == nil {
+		t.Error("unknown params should fail")
+	}
+}
+
+func TestRoute_DuplicateName(t *testing.T) {
+	engine := New()
+	engine.GET("/a", func(c *Context) {}).Name("dup")
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("duplicate route name should panic")
+		}
+	}()
+	engine.GET("/b", func(c *Context) {}).Name("dup")
+}
+
+func TestTemplate_URLFunc(t *testing.T) {
+	dir := t.TempDir()
+	tmpl := `<a href="{{url "user" "id" .ID}}">profile</a>`
+	if err := os.WriteFile(filepath.Join(dir, "link.tmpl"), []byte(tmpl), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	engine := New()
+	if err := engine.template.Load(filepath.Join(dir, "*.tmpl")); err != nil {
+		t.Fatal(err)
+	}
+	engine.Group("/admin").GET("/users/:id", func(c *Context) {
+		c.View("link.tmpl", H{"ID": c.Param("id")})
+	}).Name("user")
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/admin/users/9", nil)
+	engine.ServeHTTP(w, req)
+
+	if body := w.Body.String(); body != `<a href="/admin/users/9">profile</a>` {
+		t.Errorf("unexpected body: %s", body)
+	}
+}
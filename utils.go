@@ -2,6 +2,10 @@ package gooo
 
 import (
 	"os"
+	"path"
+	"reflect"
+	"runtime"
+	"strings"
 )
 
 // ResolveAddress resolves the address to listen on.
@@ -26,3 +30,31 @@ func isDirExist(path string) bool {
 	}
 	return fi.IsDir()
 }
+
+// nameOfFunction 返回函数的完整名称, 用于路由表展示
+func nameOfFunction(f any) string {
+	return runtime.FuncForPC(reflect.ValueOf(f).Pointer()).Name()
+}
+
+// cleanPath 返回规范化的URL路径: 去除多余斜杠和 . / .. 片段, 保留结尾斜杠
+func cleanPath(p string) string {
+	if p == "" {
+		return "/"
+	}
+	if p[0] != '/' {
+		p = "/" + p
+	}
+	cleaned := path.Clean(p)
+	if p[len(p)-1] == '/' && cleaned != "/" {
+		cleaned += "/"
+	}
+	return cleaned
+}
+
+// toggleTrailingSlash 添加或去除路径的结尾斜杠
+func toggleTrailingSlash(p string) string {
+	if strings.HasSuffix(p, "/") {
+		return strings.TrimSuffix(p, "/")
+	}
+	return p + "/"
+}
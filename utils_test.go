@@ -28,3 +28,20 @@ func TestResolveAddressWithTooManyParams(t *testing.T) {
 	}()
 	resolveAddress([]string{"127.0.0.1:8080", "127.0.0.1:8081"})
 }
+
+func TestCleanPath(t *testing.T) {
+	tests := map[string]string{
+		"":            "/",
+		"/":           "/",
+		"users":       "/users",
+		"/users/":     "/users/",
+		"//users//5":  "/users/5",
+		"/a/./b/../c": "/a/c",
+		"/a/b/..//":   "/a/",
+	}
+	for in, expected := range tests {
+		if got := cleanPath(in); got != expected {
+			t.Errorf("cleanPath(%q): expected %q, got %q", in, expected, got)
+		}
+	}
+}
@@ -0,0 +1,332 @@
+package gooo
+
+import (
+	"errors"
+	"fmt"
+	"net/mail"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// FieldError 单个字段的校验错误
+type FieldError struct {
+	Field   string `json:"field"`   // 字段路径, 优先使用 json 标签名, 如 address.city / tags[1]
+	Rule    string `json:"rule"`    // 未通过的规则名
+	Param   string `json:"param"`   // 规则参数
+	Message string `json:"message"` // 可读的错误信息
+}
+
+func (e FieldError) Error() string {
+	return e.Message
+}
+
+// ValidationErrors 结构体校验失败的全部字段错误
+type ValidationErrors []FieldError
+
+func (ve ValidationErrors) Error() string {
+	msgs := make([]string, len(ve))
+	for i, e := range ve {
+		msgs[i] = e.Message
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// validateRule 解析后的单条规则
+type validateRule struct {
+	name  string
+	param string
+	re    *regexp.Regexp
+}
+
+// validateField 结构体字段及其规则
+type validateField struct {
+	index int
+	name  string
+	rules []validateRule
+}
+
+// structValidation 结构体类型解析后的规则, 标签有误时 err 非空
+type structValidation struct {
+	fields []validateField
+	err    error
+}
+
+// validateCache 缓存每个结构体类型解析后的规则(包括解析失败的结果)
+var validateCache sync.Map // map[reflect.Type]*structValidation
+
+// ErrInvalidValidateTag validate 标签有误, 属于代码错误而非请求数据错误
+var ErrInvalidValidateTag = errors.New("invalid validate tag")
+
+// Validate 按 validate 标签校验结构体, 校验失败时返回 ValidationErrors.
+// 规则以逗号分隔:
+//
+//	required       值不能为零值(字符串非空、切片非空、指针非 nil)
+//	min=n / max=n  字符串长度、切片长度或数值的上下限
+//	len=n          字符串长度或切片长度必须等于 n
+//	email          合法的邮箱地址
+//	oneof=a b c    值必须是空格分隔的候选之一
+//	regexp=expr    字符串必须匹配正则(表达式中不能包含逗号)
+//	dive           之后的规则作用于切片/数组/map 的每个元素
+//
+// 未设置 required 的字段为零值时跳过其余规则; 嵌套结构体(即使未赋值)会递归校验.
+// 标签有误时返回包装了 ErrInvalidValidateTag 的错误, 每个类型只解析一次.
+func Validate(obj any) error {
+	rv := reflect.ValueOf(obj)
+	for rv.Kind() == reflect.Pointer {
+		if rv.IsNil() {
+			return nil
+		}
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return nil
+	}
+
+	var errs ValidationErrors
+	if err := validateStruct(rv, "", &errs); err != nil {
+		return err
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+func validateStruct(rv reflect.Value, prefix string, errs *ValidationErrors) error {
+	sv := structRules(rv.Type())
+	if sv.err != nil {
+		return sv.err
+	}
+	for _, f := range sv.fields {
+		if err := validateValue(rv.Field(f.index), prefix+f.name, f.rules, errs); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateValue 按规则校验值, 遇到 dive 时对每个元素应用剩余规则
+func validateValue(v reflect.Value, field string, rules []validateRule, errs *ValidationErrors) error {
+	required := false
+	for _, r := range rules {
+		if r.name == "dive" {
+			break
+		}
+		if r.name == "required" {
+			required = true
+		}
+	}
+	if isEmptyValue(v) {
+		if required {
+			*errs = append(*errs, FieldError{Field: field, Rule: "required", Message: field + " is required"})
+			return nil
+		}
+		// 未赋值的嵌套结构体值仍需校验其字段, 如字段上的 required; nil 指针则跳过
+		if v.Kind() == reflect.Struct && v.Type() != timeType {
+			return validateStruct(v, field+".", errs)
+		}
+		return nil
+	}
+
+	for i, r := range rules {
+		if r.name == "dive" {
+			return diveValue(v, field, rules[i+1:], errs)
+		}
+		if msg := checkRule(v, r); msg != "" {
+			*errs = append(*errs, FieldError{Field: field, Rule: r.name, Param: r.param, Message: field + " " + msg})
+			return nil
+		}
+	}
+
+	// 递归校验嵌套结构体
+	for v.Kind() == reflect.Pointer {
+		v = v.Elem()
+	}
+	if v.Kind() == reflect.Struct && v.Type() != timeType {
+		return validateStruct(v, field+".", errs)
+	}
+	return nil
+}
+
+func diveValue(v reflect.Value, field string, rules []validateRule, errs *ValidationErrors) error {
+	for v.Kind() == reflect.Pointer {
+		v = v.Elem()
+	}
+	switch v.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			if err := validateValue(v.Index(i), fmt.Sprintf("%s[%d]", field, i), rules, errs); err != nil {
+				return err
+			}
+		}
+	case reflect.Map:
+		iter := v.MapRange()
+		for iter.Next() {
+			if err := validateValue(iter.Value(), fmt.Sprintf("%s[%v]", field, iter.Key()), rules, errs); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// checkRule 校验单条规则, 通过时返回空串
+func checkRule(v reflect.Value, r validateRule) string {
+	for v.Kind() == reflect.Pointer {
+		v = v.Elem()
+	}
+	switch r.name {
+	case "required":
+		return ""
+	case "min", "max", "len":
+		return checkSize(v, r)
+	case "email":
+		s := fmt.Sprint(v.Interface())
+		if addr, err := mail.ParseAddress(s); err != nil || addr.Address != s {
+			return "must be a valid email address"
+		}
+	case "oneof":
+		s := fmt.Sprint(v.Interface())
+		for _, option := range strings.Fields(r.param) {
+			if s == option {
+				return ""
+			}
+		}
+		return "must be one of [" + r.param + "]"
+	case "regexp":
+		if !r.re.MatchString(fmt.Sprint(v.Interface())) {
+			return "must match " + r.param
+		}
+	}
+	return ""
+}
+
+// checkSize 校验 min/max/len: 字符串按字符数, 集合按长度, 数值按大小
+func checkSize(v reflect.Value, r validateRule) string {
+	limit, err := strconv.ParseFloat(r.param, 64)
+	if err != nil {
+		return "has invalid rule " + r.name + "=" + r.param
+	}
+
+	var size float64
+	unit := ""
+	switch v.Kind() {
+	case reflect.String:
+		size, unit = float64(utf8.RuneCountInString(v.String())), " characters"
+	case reflect.Slice, reflect.Array, reflect.Map:
+		size, unit = float64(v.Len()), " items"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		size = float64(v.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		size = float64(v.Uint())
+	case reflect.Float32, reflect.Float64:
+		size = v.Float()
+	default:
+		return ""
+	}
+
+	switch {
+	case r.name == "min" && size < limit:
+		if unit == "" {
+			return "must be at least " + r.param
+		}
+		return "must contain at least " + r.param + unit
+	case r.name == "max" && size > limit:
+		if unit == "" {
+			return "must be at most " + r.param
+		}
+		return "must contain at most " + r.param + unit
+	case r.name == "len" && size != limit:
+		if unit == "" {
+			return "must be " + r.param
+		}
+		return "must contain exactly " + r.param + unit
+	}
+	return ""
+}
+
+// isEmptyValue 判断值是否为零值, 切片和 map 按长度判断
+func isEmptyValue(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Slice, reflect.Map:
+		return v.Len() == 0
+	case reflect.Invalid:
+		return true
+	default:
+		return v.IsZero()
+	}
+}
+
+// structRules 解析并缓存结构体的校验规则, 解析失败的结果同样缓存
+func structRules(t reflect.Type) *structValidation {
+	if cached, ok := validateCache.Load(t); ok {
+		return cached.(*structValidation)
+	}
+
+	sv := &structValidation{fields: make([]validateField, 0)}
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+		tag := sf.Tag.Get("validate")
+		if tag == "-" {
+			continue
+		}
+		rules, err := parseRules(t, sf, tag)
+		if err != nil {
+			sv.err = err
+			DebugPrint("校验规则有误: %v", err)
+			break
+		}
+		// 没有规则的嵌套结构体仍需递归校验
+		if len(rules) == 0 && !isNestedStruct(sf.Type) {
+			continue
+		}
+		name := sf.Name
+		if jsonName, _, _ := strings.Cut(sf.Tag.Get("json"), ","); jsonName != "" && jsonName != "-" {
+			name = jsonName
+		}
+		sv.fields = append(sv.fields, validateField{index: i, name: name, rules: rules})
+	}
+
+	cached, _ := validateCache.LoadOrStore(t, sv)
+	return cached.(*structValidation)
+}
+
+// parseRules 解析 validate 标签, 规则有误时返回包装了 ErrInvalidValidateTag 的错误
+func parseRules(t reflect.Type, sf reflect.StructField, tag string) ([]validateRule, error) {
+	rules := make([]validateRule, 0)
+	if tag == "" {
+		return rules, nil
+	}
+	for _, item := range strings.Split(tag, ",") {
+		name, param, _ := strings.Cut(strings.TrimSpace(item), "=")
+		r := validateRule{name: name, param: param}
+		switch name {
+		case "required", "email", "dive":
+		case "min", "max", "len":
+			if _, err := strconv.ParseFloat(param, 64); err != nil {
+				return nil, fmt.Errorf("%w: %s.%s %s", ErrInvalidValidateTag, t.Name(), sf.Name, item)
+			}
+		case "oneof":
+			if param == "" {
+				return nil, fmt.Errorf("%w: %s.%s %s", ErrInvalidValidateTag, t.Name(), sf.Name, item)
+			}
+		case "regexp":
+			re, err := regexp.Compile(param)
+			if err != nil {
+				return nil, fmt.Errorf("%w: %s.%s %s: %v", ErrInvalidValidateTag, t.Name(), sf.Name, item, err)
+			}
+			r.re = re
+		default:
+			return nil, fmt.Errorf("%w: %s.%s unknown rule %s", ErrInvalidValidateTag, t.Name(), sf.Name, name)
+		}
+		rules = append(rules, r)
+	}
+	return rules, nil
+}
@@ -0,0 +1,183 @@
+package gooo
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type validateAddress struct {
+	City string `json:"city" validate:"required"`
+	Zip  string `json:"zip" validate:"len=5,regexp=^[0-9]+$"`
+}
+
+type validateUser struct {
+	Name    string            `json:"name" validate:"required,min=2,max=10"`
+	Email   string            `json:"email" validate:"required,email"`
+	Age     int               `json:"age" validate:"min=18,max=130"`
+	Role    string            `json:"role" validate:"oneof=admin user"`
+	Tags    []string          `json:"tags" validate:"max=3,dive,required,min=2"`
+	Address validateAddress   `json:"address"`
+	Backup  *validateAddress  `json:"backup"`
+	Labels  map[string]string `json:"labels" validate:"dive,max=4"`
+	Note    string            `validate:"-"`
+}
+
+func validUser() validateUser {
+	return validateUser{
+		Name:    "alice",
+		Email:   "alice@example.com",
+		Age:     30,
+		Role:    "admin",
+		Tags:    []string{"go", "web"},
+		Address: validateAddress{City: "Paris", Zip: "75001"},
+	}
+}
+
+func TestValidate_Valid(t *testing.T) {
+	u := validUser()
+	if err := Validate(&u); err != nil {
+		t.Fatalf("Expected valid struct, got %v", err)
+	}
+	// 非必填字段为零值时跳过其余规则
+	u.Age, u.Role, u.Tags = 0, "", nil
+	if err := Validate(u); err != nil {
+		t.Fatalf("Expected optional zero values to pass, got %v", err)
+	}
+}
+
+func TestValidate_Errors(t *testing.T) {
+	u := validUser()
+	u.Name = "a"
+	u.Email = "not-an-email"
+	u.Age = 10
+	u.Role = "guest"
+	u.Tags = []string{"go", "x"}
+	u.Address.Zip = "75a01"
+	u.Backup = &validateAddress{Zip: "12345"}
+	u.Labels = map[string]string{"k": "too-long"}
+
+	err := Validate(&u)
+	var verrs ValidationErrors
+	if !errors.As(err, &verrs) {
+		t.Fatalf("Expected ValidationErrors, got %v", err)
+	}
+
+	expected := map[string]string{
+		"name":        "min",
+		"email":       "email",
+		"age":         "min",
+		"role":        "oneof",
+		"tags[1]":     "min",
+		"address.zip": "regexp",
+		"backup.city": "required",
+		"labels[k]":   "max",
+	}
+	if len(verrs) != len(expected) {
+		t.Fatalf("Expected %d errors, got %d: %v", len(expected), len(verrs), verrs)
+	}
+	for _, fe := range verrs {
+		if rule, ok := expected[fe.Field]; !ok || rule != fe.Rule {
+			t.Errorf("Unexpected error %+v", fe)
+		}
+	}
+	if !strings.Contains(err.Error(), "name must contain at least 2 characters") {
+		t.Errorf("Unexpected message: %s", err.Error())
+	}
+}
+
+func TestValidate_InvalidRule(t *testing.T) {
+	type bad struct {
+		Name string `validate:"gte=1"`
+	}
+	// 标签有误时返回错误而非 panic, 结果被缓存, 重复校验得到同样的错误
+	for i := 0; i < 2; i++ {
+		err := Validate(&bad{Name: "x"})
+		if !errors.Is(err, ErrInvalidValidateTag) {
+			t.Fatalf("Expected ErrInvalidValidateTag, got %v", err)
+		}
+	}
+
+	type wrapper struct {
+		Inner bad
+	}
+	if err := Validate(&wrapper{}); !errors.Is(err, ErrInvalidValidateTag) {
+		t.Errorf("Expected nested ErrInvalidValidateTag, got %v", err)
+	}
+
+	engine := New()
+	engine.POST("/bad", func(c *Context) {
+		var b bad
+		if err := c.Bind(&b); err != nil {
+			return
+		}
+		c.String(http.StatusOK, "ok")
+	})
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("POST", "/bad", strings.NewReader(`{"Name":"x"}`))
+	req.Header.Set("Content-Type", MIMEJSON)
+	engine.ServeHTTP(w, req)
+	if w.Code != http.StatusInternalServerError {
+		t.Errorf("Expected status 500 for invalid tag, got %d", w.Code)
+	}
+}
+
+func TestValidate_ZeroNestedStruct(t *testing.T) {
+	// 未赋值的嵌套结构体仍需校验其字段
+	u := validUser()
+	u.Address = validateAddress{}
+	err := Validate(&u)
+	var verrs ValidationErrors
+	if !errors.As(err, &verrs) || len(verrs) != 1 || verrs[0].Field != "address.city" || verrs[0].Rule != "required" {
+		t.Fatalf("Expected address.city required error, got %v", err)
+	}
+
+	// nil 指针跳过
+	u = validUser()
+	u.Backup = nil
+	if err := Validate(&u); err != nil {
+		t.Errorf("Expected nil pointer to be skipped, got %v", err)
+	}
+}
+
+func TestBind_ValidationResponse(t *testing.T) {
+	engine := New()
+	engine.POST("/users", func(c *Context) {
+		var u validateUser
+		if err := c.Bind(&u); err != nil {
+			return
+		}
+		c.String(http.StatusCreated, u.Name)
+	})
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("POST", "/users", strings.NewReader(`{"name":"b","email":"bob"}`))
+	req.Header.Set("Content-Type", MIMEJSON)
+	engine.ServeHTTP(w, req)
+
+	if w.Code != http.StatusUnprocessableEntity {
+		t.Fatalf("Expected status 422, got %d", w.Code)
+	}
+	var body struct {
+		Error  string       `json:"error"`
+		Fields []FieldError `json:"fields"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+		t.Fatalf("Invalid JSON body: %v", err)
+	}
+	// name, email 以及未赋值的 address 中的 address.city
+	if body.Error != "validation failed" || len(body.Fields) != 3 {
+		t.Errorf("Unexpected body: %s", w.Body.String())
+	}
+
+	w = httptest.NewRecorder()
+	req = httptest.NewRequest("POST", "/users", strings.NewReader(`{"name":`))
+	req.Header.Set("Content-Type", MIMEJSON)
+	engine.ServeHTTP(w, req)
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status 400 for malformed JSON, got %d", w.Code)
+	}
+}
@@ -0,0 +1,275 @@
+package gooo
+
+import (
+	"encoding"
+	"encoding/base64"
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// marshalYAML 将数据编码为 YAML 文本, 只支持输出所需的子集:
+// 映射的键按字母顺序输出, 结构体按字段顺序输出(支持 yaml / json 标签和 omitempty),
+// 时间按 RFC3339 输出, 实现了 encoding.TextMarshaler 的类型输出其文本, []byte 输出 base64.
+func marshalYAML(v any) ([]byte, error) {
+	text, block, err := yamlNode(reflect.ValueOf(v), 0)
+	if err != nil {
+		return nil, err
+	}
+	if !block {
+		text += "\n"
+	}
+	return []byte(text), nil
+}
+
+// yamlNode 编码单个值. 标量和空集合返回单行文本(block 为 false),
+// 非空的映射和序列返回以 indent 个空格缩进的多行文本(block 为 true)
+func yamlNode(v reflect.Value, indent int) (text string, block bool, err error) {
+	for v.Kind() == reflect.Interface || v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return "null", false, nil
+		}
+		v = v.Elem()
+	}
+	if !v.IsValid() {
+		return "null", false, nil
+	}
+
+	switch v.Type() {
+	case timeType:
+		// 时间按时间戳输出, 不加引号
+		return v.Interface().(time.Time).Format(time.RFC3339Nano), false, nil
+	case durationType:
+		return yamlString(v.Interface().(time.Duration).String()), false, nil
+	}
+	if m, ok := textMarshaler(v); ok {
+		b, err := m.MarshalText()
+		if err != nil {
+			return "", false, err
+		}
+		return yamlString(string(b)), false, nil
+	}
+
+	switch v.Kind() {
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool()), false, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10), false, nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.FormatUint(v.Uint(), 10), false, nil
+	case reflect.Float32, reflect.Float64:
+		return yamlFloat(v.Float(), v.Type().Bits()), false, nil
+	case reflect.String:
+		return yamlString(v.String()), false, nil
+	case reflect.Slice, reflect.Array:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			b := make([]byte, v.Len())
+			reflect.Copy(reflect.ValueOf(b), v)
+			return yamlString(base64.StdEncoding.EncodeToString(b)), false, nil
+		}
+		return yamlSequence(v, indent)
+	case reflect.Map:
+		return yamlMap(v, indent)
+	case reflect.Struct:
+		return yamlStruct(v, indent)
+	default:
+		return "", false, fmt.Errorf("yaml: unsupported type %s", v.Type())
+	}
+}
+
+// textMarshaler 返回值或其指针实现的 encoding.TextMarshaler
+func textMarshaler(v reflect.Value) (encoding.TextMarshaler, bool) {
+	if v.Type().Implements(textMarshalerType) {
+		return v.Interface().(encoding.TextMarshaler), true
+	}
+	if v.CanAddr() && v.Addr().Type().Implements(textMarshalerType) {
+		return v.Addr().Interface().(encoding.TextMarshaler), true
+	}
+	return nil, false
+}
+
+var textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+
+func yamlSequence(v reflect.Value, indent int) (string, bool, error) {
+	if v.Len() == 0 {
+		return "[]", false, nil
+	}
+	var buf strings.Builder
+	prefix := strings.Repeat(" ", indent)
+	for i := 0; i < v.Len(); i++ {
+		text, block, err := yamlNode(v.Index(i), indent+2)
+		if err != nil {
+			return "", false, err
+		}
+		buf.WriteString(prefix + "- ")
+		if block {
+			// 块的第一行与 "- " 写在同一行
+			buf.WriteString(text[indent+2:])
+		} else {
+			buf.WriteString(text + "\n")
+		}
+	}
+	return buf.String(), true, nil
+}
+
+func yamlMap(v reflect.Value, indent int) (string, bool, error) {
+	if v.Len() == 0 {
+		return "{}", false, nil
+	}
+	keys := v.MapKeys()
+	names := make([]string, len(keys))
+	for i, k := range keys {
+		names[i] = fmt.Sprint(k.Interface())
+	}
+	order := make([]int, len(keys))
+	for i := range order {
+		order[i] = i
+	}
+	sort.Slice(order, func(a, b int) bool { return names[order[a]] < names[order[b]] })
+
+	var buf strings.Builder
+	for _, i := range order {
+		if err := yamlPair(&buf, indent, names[i], v.MapIndex(keys[i])); err != nil {
+			return "", false, err
+		}
+	}
+	return buf.String(), true, nil
+}
+
+func yamlStruct(v reflect.Value, indent int) (string, bool, error) {
+	var buf strings.Builder
+	if err := yamlFields(&buf, v, indent); err != nil {
+		return "", false, err
+	}
+	if buf.Len() == 0 {
+		return "{}", false, nil
+	}
+	return buf.String(), true, nil
+}
+
+// yamlFields 输出结构体字段, 未设置标签的嵌入结构体字段提升到外层
+func yamlFields(buf *strings.Builder, v reflect.Value, indent int) error {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() && !field.Anonymous {
+			continue
+		}
+		tag := field.Tag.Get("yaml")
+		if tag == "" {
+			tag = field.Tag.Get("json")
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+		if name == "-" {
+			continue
+		}
+		fv := v.Field(i)
+		if field.Anonymous && name == "" {
+			ft := field.Type
+			if ft.Kind() == reflect.Pointer {
+				if fv.IsNil() {
+					continue
+				}
+				ft, fv = ft.Elem(), fv.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				if err := yamlFields(buf, fv, indent); err != nil {
+					return err
+				}
+				continue
+			}
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if strings.Contains(","+opts+",", ",omitempty,") && isEmptyValue(fv) {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		if err := yamlPair(buf, indent, name, fv); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// yamlPair 输出一个键值对, 块值换行缩进
+func yamlPair(buf *strings.Builder, indent int, key string, v reflect.Value) error {
+	text, block, err := yamlNode(v, indent+2)
+	if err != nil {
+		return err
+	}
+	buf.WriteString(strings.Repeat(" ", indent) + yamlString(key) + ":")
+	if block {
+		buf.WriteString("\n" + text)
+	} else {
+		buf.WriteString(" " + text + "\n")
+	}
+	return nil
+}
+
+func yamlFloat(f float64, bits int) string {
+	switch {
+	case math.IsNaN(f):
+		return ".nan"
+	case math.IsInf(f, 1):
+		return ".inf"
+	case math.IsInf(f, -1):
+		return "-.inf"
+	}
+	return strconv.FormatFloat(f, 'g', -1, bits)
+}
+
+// yamlString 输出字符串, 可能被解析为其他类型或含有特殊字符时使用双引号
+func yamlString(s string) string {
+	if yamlNeedsQuote(s) {
+		return strconv.Quote(s)
+	}
+	return s
+}
+
+// yamlResolvedPattern YAML 1.1 中会被解析为非字符串的形式: 浮点数(含 .inf / .nan)、
+// 带下划线或二进制的整数、六十进制数、时间戳、合并键 << 和值 =
+var yamlResolvedPattern = regexp.MustCompile(`^(?:` +
+	`[-+]?\.(?i:inf)|\.(?i:nan)|<<|=` +
+	`|[-+]?(?:[0-9][0-9_]*)?\.[0-9_]*(?:[eE][-+]?[0-9]+)?` +
+	`|[-+]?(?:0b[01_]+|0o?[0-7_]+|0x[0-9a-fA-F_]+|[0-9][0-9_]*)` +
+	`|[-+]?[0-9][0-9_]*(?::[0-5]?[0-9])+(?:\.[0-9_]*)?` +
+	`|[0-9]{4}-[0-9]{1,2}-[0-9]{1,2}(?:[Tt ].*)?` +
+	`)$`)
+
+func yamlNeedsQuote(s string) bool {
+	if s == "" {
+		return true
+	}
+	switch strings.ToLower(s) {
+	case "true", "false", "yes", "no", "on", "off", "y", "n", "null", "~":
+		return true
+	}
+	if yamlResolvedPattern.MatchString(s) {
+		return true
+	}
+	if _, err := strconv.ParseFloat(s, 64); err == nil {
+		return true
+	}
+	if _, err := strconv.ParseInt(s, 0, 64); err == nil {
+		return true
+	}
+	if strings.ContainsRune("-?:,[]{}#&*!|>'\"%@` \t", rune(s[0])) {
+		return true
+	}
+	if last := s[len(s)-1]; last == ' ' || last == '\t' || last == ':' {
+		return true
+	}
+	if strings.Contains(s, ": ") || strings.Contains(s, " #") {
+		return true
+	}
+	return strconv.Quote(s) != `"`+s+`"`
+}